@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"syscall"
+	"time"
 
 	"go-antinuke-2.0/internal/bot"
 	"go-antinuke-2.0/internal/commands"
@@ -15,6 +16,7 @@ import (
 	"go-antinuke-2.0/internal/database"
 	"go-antinuke-2.0/internal/decision"
 	"go-antinuke-2.0/internal/dispatcher"
+	"go-antinuke-2.0/internal/forensics"
 	"go-antinuke-2.0/internal/ingest"
 	"go-antinuke-2.0/internal/logging"
 	"go-antinuke-2.0/internal/notifier"
@@ -46,10 +48,14 @@ func main() {
 	components := startComponents(cfg)
 
 	// Initialize bot AFTER creating ring buffer
-	if err := initializeBot(cfg.Bot.Token, components.ringBuffer); err != nil {
+	if err := initializeBot(cfg, components.ringBuffer); err != nil {
 		panic(err)
 	}
 
+	if cfg.Network.CustomGateway() {
+		components.gatewayReaders = startGatewayReaders(cfg, components.ringBuffer)
+	}
+
 	logging.Info("All components started successfully")
 	logging.Info("Correlator running on CPU %d", cfg.Runtime.CorrelatorCPU)
 	logging.Info("Detection target: <1µs, Execution target: <200ms")
@@ -108,6 +114,7 @@ func initializeState() error {
 
 	config.InitThresholds()
 	config.InitGuildProfiles()
+	forensics.InitAssetBackup(config.Get().Forensics.SnapshotPath)
 
 	state.TouchAll()
 
@@ -135,10 +142,10 @@ func initializeDatabase() error {
 	return nil
 }
 
-func initializeBot(token string, ringBuffer *ingest.RingBuffer) error {
+func initializeBot(cfg *config.Config, ringBuffer *ingest.RingBuffer) error {
 	fmt.Println("Initializing Discord bot...")
 
-	if err := bot.Initialize(token); err != nil {
+	if err := bot.Initialize(cfg.Bot.Token, cfg.Network.MinShards); err != nil {
 		return err
 	}
 
@@ -181,6 +188,7 @@ type Components struct {
 	httpPool       *dispatcher.HTTPPool
 	rateLimiter    *dispatcher.RateLimitMonitor
 	workers        []*dispatcher.RESTWorker
+	gatewayReaders []*ingest.GatewayReader
 }
 
 func startComponents(cfg *config.Config) *Components {
@@ -188,27 +196,12 @@ func startComponents(cfg *config.Config) *Components {
 	alertQueue := correlator.NewAlertQueue(32768)
 	jobQueue := decision.NewJobQueue(16384)
 
-	// Gateway intents:
-	// 1<<0 = GUILDS
-	// 1<<1 = GUILD_MEMBERS
-	// 1<<7 = GUILD_AUDIT_LOG (CRITICAL for actor detection)
-	// 1<<9 = GUILD_MESSAGES
-	intents := 1<<0 | 1<<1 | 1<<7 | 1<<9
-
-	// NOTE: We use discordgo's built-in websocket connection instead of a custom GatewayReader
-	// The bot session handles the websocket connection and event processing
-	_ = intents // Intents are set on the bot session, not the custom gateway reader
-
-	// gatewayReader := ingest.NewGatewayReader(cfg.Bot.Token, intents, ringBuffer, cfg.Runtime.IngestCPU)
-	// if err := gatewayReader.Connect(); err != nil {
-	// 	panic(fmt.Sprintf("Gateway connection failed: %v", err))
-	// }
-	// go gatewayReader.ReadLoop()
-
 	correlatorInst := correlator.NewCorrelator(ringBuffer, alertQueue, cfg.Runtime.CorrelatorCPU)
 	go correlatorInst.Start()
 
 	decisionEngine := decision.NewDecisionEngine(alertQueue, jobQueue, cfg.Runtime.DecisionCPU)
+	decisionEngine.SetRevertRoleGrants(cfg.Detection.RevertRoleGrants)
+	decisionEngine.SetTimeoutSeconds(cfg.Detection.TimeoutSeconds)
 	go decisionEngine.Start()
 
 	httpPool := dispatcher.NewHTTPPool(cfg.Network.HTTPPoolSize)
@@ -235,6 +228,60 @@ func startComponents(cfg *config.Config) *Components {
 	}
 }
 
+// startGatewayReaders opens one custom gateway connection per shard, each
+// slicing its shard's audit log entries into the ring buffer. The discordgo
+// shards keep handling everything else, and hand the sliced actions back to
+// discordgo whenever their reader is not live. Readers identify in the same
+// max_concurrency buckets as the shards, after the shards' last bucket.
+func startGatewayReaders(cfg *config.Config, ringBuffer *ingest.RingBuffer) []*ingest.GatewayReader {
+	session := bot.GetSession()
+	shardCount := session.ShardCount()
+	concurrency := session.MaxConcurrency()
+
+	// Readers sharing one pinned core would queue behind each other, so only
+	// a single reader is pinned
+	cpuCore := cfg.Runtime.IngestCPU
+	if shardCount > 1 {
+		cpuCore = -1
+	}
+
+	var readers []*ingest.GatewayReader
+	for shardID := 0; shardID < shardCount; shardID++ {
+		// The shards identified their last bucket just before this
+		if shardID%concurrency == 0 {
+			time.Sleep(bot.IdentifyInterval)
+		}
+
+		// 1<<2 = GUILD_MODERATION, which carries GUILD_AUDIT_LOG_ENTRY_CREATE
+		gatewayReader := ingest.NewGatewayReader(cfg.Bot.Token, 1<<2, ringBuffer, cpuCore)
+		gatewayReader.SetCompression(cfg.Network.GatewayCompress)
+		gatewayReader.SetShard(shardID, shardCount)
+		gatewayReader.SetActorFilter(session.IsBotActor)
+		gatewayReader.SetStateHandler(func(live bool) {
+			bot.SetGatewayIngest(shardID, live)
+		})
+
+		if err := gatewayReader.Connect(); err != nil {
+			logging.Error("Custom gateway connection for shard %d failed, staying on discordgo ingest: %v", shardID, err)
+			continue
+		}
+
+		go func(shardID int) {
+			if err := gatewayReader.ReadLoop(); err != nil {
+				logging.Error("Custom gateway for shard %d stopped, falling back to discordgo ingest: %v", shardID, err)
+			}
+		}(shardID)
+		readers = append(readers, gatewayReader)
+	}
+
+	if cpuCore >= 0 {
+		logging.Info("Custom gateway readers connected for %d of %d shard(s) on CPU %d", len(readers), shardCount, cpuCore)
+	} else {
+		logging.Info("Custom gateway readers connected for %d of %d shard(s), unpinned", len(readers), shardCount)
+	}
+	return readers
+}
+
 func waitForShutdown() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -250,5 +297,7 @@ func stopComponents(components *Components) {
 		worker.Stop()
 	}
 
-	// Note: Gateway connection is handled by discordgo session
+	for _, gatewayReader := range components.gatewayReaders {
+		gatewayReader.Close()
+	}
 }
@@ -118,6 +118,7 @@ func (b *Bootstrap) initializeState() error {
 	config.InitThresholds()
 	config.InitGuildProfiles()
 	forensics.InitRecoveryTracker()
+	forensics.InitAssetBackup(b.Config.Forensics.SnapshotPath)
 	state.TouchAll()
 
 	logging.Info("State initialized")
@@ -21,8 +21,10 @@ func Shutdown(c *Components) error {
 		c.Watchdog.Stop()
 	}
 
-	logging.Info("Stopping gateway reader...")
-	c.GatewayReader.Close()
+	if c.GatewayReader != nil {
+		logging.Info("Stopping gateway reader...")
+		c.GatewayReader.Close()
+	}
 
 	logging.Info("Stopping correlator...")
 	c.Correlator.Stop()
@@ -42,13 +42,18 @@ func Wire(b *Bootstrap) error {
 	alertQueue := correlator.NewAlertQueue(32768)
 	jobQueue := decision.NewJobQueue(16384)
 
-	intents := 1<<0 | 1<<1 | 1<<9
-	gatewayReader := ingest.NewGatewayReader(
-		b.Config.Bot.Token,
-		intents,
-		ringBuffer,
-		b.Config.Runtime.IngestCPU,
-	)
+	// The custom reader only slices audit log entries, which need
+	// GUILD_MODERATION (1<<2) alone
+	var gatewayReader *ingest.GatewayReader
+	if b.Config.Network.CustomGateway() {
+		gatewayReader = ingest.NewGatewayReader(
+			b.Config.Bot.Token,
+			1<<2,
+			ringBuffer,
+			b.Config.Runtime.IngestCPU,
+		)
+		gatewayReader.SetCompression(b.Config.Network.GatewayCompress)
+	}
 
 	correlatorInst := correlator.NewCorrelator(
 		ringBuffer,
@@ -61,6 +66,8 @@ func Wire(b *Bootstrap) error {
 		jobQueue,
 		b.Config.Runtime.DecisionCPU,
 	)
+	decisionEngine.SetRevertRoleGrants(b.Config.Detection.RevertRoleGrants)
+	decisionEngine.SetTimeoutSeconds(b.Config.Detection.TimeoutSeconds)
 
 	httpPool := dispatcher.NewHTTPPool(b.Config.Network.HTTPPoolSize)
 	rateLimiter := dispatcher.NewRateLimitMonitor()
@@ -131,12 +138,18 @@ func StartAll(c *Components) error {
 		logging.Info("HA heartbeat started")
 	}
 
-	if err := c.GatewayReader.Connect(); err != nil {
-		return fmt.Errorf("gateway connection failed: %w", err)
-	}
+	if c.GatewayReader != nil {
+		if err := c.GatewayReader.Connect(); err != nil {
+			return fmt.Errorf("gateway connection failed: %w", err)
+		}
 
-	go c.GatewayReader.ReadLoop()
-	logging.Info("Gateway reader started")
+		go func() {
+			if err := c.GatewayReader.ReadLoop(); err != nil {
+				logging.Error("Gateway reader stopped: %v", err)
+			}
+		}()
+		logging.Info("Gateway reader started")
+	}
 
 	go c.Correlator.Start()
 	logging.Info("Correlator started on isolated CPU")
@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-antinuke-2.0/internal/forensics"
+	"go-antinuke-2.0/internal/logging"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxAssetSize caps a downloaded emoji or sticker; Discord's own upload limit is 512KB
+const maxAssetSize = 1 << 20
+
+func emojiAssets(emojis []*discordgo.Emoji) map[uint64]string {
+	assets := make(map[uint64]string, len(emojis))
+	for _, emoji := range emojis {
+		if id, err := strconv.ParseUint(emoji.ID, 10, 64); err == nil {
+			assets[id] = emoji.Name
+		}
+	}
+	return assets
+}
+
+func stickerAssets(stickers []*discordgo.Sticker) map[uint64]string {
+	assets := make(map[uint64]string, len(stickers))
+	for _, sticker := range stickers {
+		if id, err := strconv.ParseUint(sticker.ID, 10, 64); err == nil {
+			assets[id] = sticker.Name
+		}
+	}
+	return assets
+}
+
+// backupEmojis saves every emoji not on disk yet. It downloads from the CDN,
+// so callers run it in its own goroutine.
+func backupEmojis(sess *discordgo.Session, guildID uint64, emojis []*discordgo.Emoji) {
+	backup := forensics.GetAssetBackup()
+	for _, emoji := range emojis {
+		id, err := strconv.ParseUint(emoji.ID, 10, 64)
+		if err != nil || emoji.Managed || backup.Has(guildID, forensics.AssetKindEmoji, id) {
+			continue
+		}
+
+		url, contentType := discordgo.EndpointEmoji(emoji.ID), "image/png"
+		if emoji.Animated {
+			url, contentType = discordgo.EndpointEmojiAnimated(emoji.ID), "image/gif"
+		}
+		data, err := downloadAsset(sess, url)
+		if err != nil {
+			logging.Warn("[BACKUP] Failed to download emoji %s in guild %d: %v", emoji.ID, guildID, err)
+			continue
+		}
+
+		meta := &forensics.AssetMeta{
+			ID:          id,
+			GuildID:     guildID,
+			Kind:        forensics.AssetKindEmoji,
+			Name:        emoji.Name,
+			ContentType: contentType,
+			Animated:    emoji.Animated,
+			Roles:       emoji.Roles,
+		}
+		if err := backup.Save(meta, data); err != nil {
+			logging.Warn("[BACKUP] Failed to save emoji %s in guild %d: %v", emoji.ID, guildID, err)
+		}
+	}
+}
+
+// backupStickers saves every sticker not on disk yet. Lottie stickers are
+// skipped, only partnered and verified guilds may upload them again.
+func backupStickers(sess *discordgo.Session, guildID uint64, stickers []*discordgo.Sticker) {
+	backup := forensics.GetAssetBackup()
+	for _, sticker := range stickers {
+		id, err := strconv.ParseUint(sticker.ID, 10, 64)
+		if err != nil || backup.Has(guildID, forensics.AssetKindSticker, id) {
+			continue
+		}
+
+		var url, contentType string
+		switch sticker.FormatType {
+		case discordgo.StickerFormatTypePNG, discordgo.StickerFormatTypeAPNG:
+			url, contentType = discordgo.EndpointCDN+"stickers/"+sticker.ID+".png", "image/png"
+		case discordgo.StickerFormatTypeGIF:
+			url, contentType = discordgo.EndpointCDN+"stickers/"+sticker.ID+".gif", "image/gif"
+		default:
+			continue
+		}
+		data, err := downloadAsset(sess, url)
+		if err != nil {
+			logging.Warn("[BACKUP] Failed to download sticker %s in guild %d: %v", sticker.ID, guildID, err)
+			continue
+		}
+
+		meta := &forensics.AssetMeta{
+			ID:          id,
+			GuildID:     guildID,
+			Kind:        forensics.AssetKindSticker,
+			Name:        sticker.Name,
+			ContentType: contentType,
+			Description: sticker.Description,
+			Tags:        sticker.Tags,
+		}
+		if err := backup.Save(meta, data); err != nil {
+			logging.Warn("[BACKUP] Failed to save sticker %s in guild %d: %v", sticker.ID, guildID, err)
+		}
+	}
+}
+
+func downloadAsset(sess *discordgo.Session, url string) ([]byte, error) {
+	resp, err := sess.Client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cdn returned %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxAssetSize))
+}
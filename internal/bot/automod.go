@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"go-antinuke-2.0/internal/logging"
+	"go-antinuke-2.0/internal/state"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// seedAutoModRules caches the guild's AutoMod rules. GUILD_CREATE does not
+// carry them, so they are fetched once over REST.
+func seedAutoModRules(sess *discordgo.Session, guildID string) {
+	rules, err := sess.AutoModerationRules(guildID)
+	if err != nil {
+		logging.Warn("Failed to fetch AutoMod rules for guild %s: %v", guildID, err)
+		return
+	}
+
+	guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
+	cache := state.GetAutoModRuleCache()
+	for _, rule := range rules {
+		if ruleID, definition, ok := autoModDefinition(rule); ok {
+			cache.Set(guildIDNum, ruleID, definition)
+		}
+	}
+}
+
+// autoModDefinition encodes a rule the way the restore job sends it back.
+func autoModDefinition(rule *discordgo.AutoModerationRule) (uint64, []byte, bool) {
+	if rule == nil {
+		return 0, nil, false
+	}
+	ruleID, err := strconv.ParseUint(rule.ID, 10, 64)
+	if err != nil {
+		return 0, nil, false
+	}
+	definition, err := json.Marshal(rule)
+	if err != nil {
+		return 0, nil, false
+	}
+	return ruleID, definition, true
+}
+
+// autoModRuleEnabled reports whether an encoded rule definition is enabled.
+func autoModRuleEnabled(definition []byte) bool {
+	var rule discordgo.AutoModerationRule
+	if err := json.Unmarshal(definition, &rule); err != nil {
+		return false
+	}
+	return rule.Enabled != nil && *rule.Enabled
+}
@@ -8,6 +8,8 @@ import (
 
 	"go-antinuke-2.0/internal/config"
 	"go-antinuke-2.0/internal/database"
+	"go-antinuke-2.0/internal/detectors"
+	"go-antinuke-2.0/internal/forensics"
 	"go-antinuke-2.0/internal/ingest"
 	"go-antinuke-2.0/internal/logging"
 	"go-antinuke-2.0/internal/state"
@@ -15,10 +17,14 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
-// auditLogCache stores recent audit log entries to correlate with events
+// auditLogCache is the join buffer pairing gateway events with their audit
+// log entries. Entries are keyed by (guild, action, target) so two admins
+// acting at once never share a slot, and events that arrive first park as
+// waiters until their entry is stored. An actor of 0 marks a bot action.
 type auditLogCache struct {
 	mu      sync.RWMutex
 	entries map[string]*auditCacheEntry
+	waiters map[string][]chan uint64
 }
 
 type auditCacheEntry struct {
@@ -31,15 +37,24 @@ type auditCacheEntry struct {
 var (
 	auditCache = &auditLogCache{
 		entries: make(map[string]*auditCacheEntry),
+		waiters: make(map[string][]chan uint64),
 	}
 	cacheTTL = 10 * time.Second
+
+	// auditJoinWindow bounds how long before an event its audit entry may have
+	// arrived. Older entries about the same target belong to an earlier action.
+	auditJoinWindow = 3 * time.Second
 )
 
+func auditCacheKey(guildID string, action int, targetID uint64) string {
+	return guildID + ":" + strconv.Itoa(action) + ":" + strconv.FormatUint(targetID, 10)
+}
+
 func (c *auditLogCache) Store(guildID string, action int, actorID, targetID uint64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := guildID + ":" + strconv.Itoa(action)
+	key := auditCacheKey(guildID, action, targetID)
 	c.entries[key] = &auditCacheEntry{
 		actorID:   actorID,
 		targetID:  targetID,
@@ -47,6 +62,12 @@ func (c *auditLogCache) Store(guildID string, action int, actorID, targetID uint
 		timestamp: time.Now(),
 	}
 
+	// Hand the actor to events parked waiting for this entry
+	for _, ch := range c.waiters[key] {
+		ch <- actorID
+	}
+	delete(c.waiters, key)
+
 	// Only cleanup every 100th entry to reduce overhead
 	if len(c.entries)%100 == 0 {
 		now := time.Now()
@@ -58,168 +79,157 @@ func (c *auditLogCache) Store(guildID string, action int, actorID, targetID uint
 	}
 }
 
-func (c *auditLogCache) Get(guildID string, action int) (uint64, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// Await returns the actor of an entry for targetID that reached us within
+// auditJoinWindow, or parks until one is stored or timeout passes. The entry
+// can arrive before or after the event it explains.
+func (c *auditLogCache) Await(guildID string, action int, targetID uint64, timeout time.Duration) (uint64, bool) {
+	key := auditCacheKey(guildID, action, targetID)
 
-	key := guildID + ":" + strconv.Itoa(action)
-	if entry, exists := c.entries[key]; exists {
-		if time.Since(entry.timestamp) < cacheTTL {
-			return entry.actorID, true
-		}
+	c.mu.Lock()
+	if entry, exists := c.entries[key]; exists && time.Since(entry.timestamp) < auditJoinWindow {
+		c.mu.Unlock()
+		return entry.actorID, true
 	}
-	return 0, false
-}
+	ch := make(chan uint64, 1)
+	c.waiters[key] = append(c.waiters[key], ch)
+	c.mu.Unlock()
 
-// fetchActorFromAuditLog fetches the most recent audit log entry for a specific action
-func fetchActorFromAuditLog(sess *discordgo.Session, guildID string, actionType int, targetID uint64) uint64 {
-	// First check cache
-	if actorID, found := auditCache.Get(guildID, actionType); found {
-		return actorID
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case actorID := <-ch:
+		return actorID, true
+	case <-timer.C:
 	}
 
-	// Fetch from Discord API
-	audit, err := sess.GuildAuditLog(guildID, "", "", actionType, 1)
-	if err != nil {
-		logging.Warn("Failed to fetch audit log for guild %s action %d: %v", guildID, actionType, err)
-		return 0
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	waiting := c.waiters[key]
+	for i, w := range waiting {
+		if w == ch {
+			c.waiters[key] = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+	if len(c.waiters[key]) == 0 {
+		delete(c.waiters, key)
 	}
 
-	if len(audit.AuditLogEntries) == 0 {
-		return 0
+	// Store may have fired between the timeout and taking the lock
+	select {
+	case actorID := <-ch:
+		return actorID, true
+	default:
+		return 0, false
 	}
+}
 
-	// Get the most recent entry
-	entry := audit.AuditLogEntries[0]
+// auditMatcher pairs events with audit log entries by target and creation time
+var auditMatcher = forensics.NewAuditMatcherWithTolerance(targetMatchWindow)
 
-	// FAKE EVENT DETECTION: Detect fake audit log events
-	isFakeEvent := false
-	eventTypeName := ""
+// attributionWait bounds how long an event waits in the join buffer for its
+// audit entry before we fall back to asking Discord
+const attributionWait = 2 * time.Second
 
-	// Check for fake member-targeted events (kick, ban, unban)
-	if actionType == 20 || actionType == 22 || actionType == 23 { // KICK=20, BAN=22, UNBAN=23
-		if entry.TargetID != "" {
-			// Check if the target user actually exists in the server
-			_, err := sess.GuildMember(guildID, entry.TargetID)
-			if err != nil {
-				isFakeEvent = true
-				eventTypeName = map[int]string{20: "kick", 22: "ban", 23: "unban"}[actionType]
-			}
-		}
+// matchAuditEntry fetches recent actionType entries and returns the one about
+// targetID closest to eventTime, or nil. isBot reports whether a bot made it.
+func matchAuditEntry(sess *discordgo.Session, guildID string, actionType int, targetID uint64, eventTime time.Time) (entry *forensics.AuditLogEntry, isBot bool, err error) {
+	audit, err := sess.GuildAuditLog(guildID, "", "", actionType, 10)
+	if err != nil {
+		return nil, false, err
 	}
 
-	// Check for fake role events (role create, delete, update)
-	if actionType == 30 || actionType == 32 || actionType == 31 { // ROLE_CREATE=30, ROLE_DELETE=32, ROLE_UPDATE=31
-		if entry.TargetID != "" {
-			// Check if the role actually exists in the server
-			roles, err := sess.GuildRoles(guildID)
-			if err == nil {
-				roleExists := false
-				for _, role := range roles {
-					if role.ID == entry.TargetID {
-						roleExists = true
-						break
-					}
-				}
-				if !roleExists {
-					isFakeEvent = true
-					eventTypeName = map[int]string{30: "role create", 32: "role delete", 31: "role update"}[actionType]
-				}
-			}
-		}
+	entries := make([]forensics.AuditLogEntry, 0, len(audit.AuditLogEntries))
+	for _, e := range audit.AuditLogEntries {
+		entries = append(entries, forensics.AuditLogEntry{
+			ID:         e.ID,
+			ActionType: actionType,
+			UserID:     e.UserID,
+			TargetID:   e.TargetID,
+			Reason:     e.Reason,
+		})
 	}
 
-	// Check for fake channel events (channel create, delete, update)
-	if actionType == 10 || actionType == 12 || actionType == 11 { // CHANNEL_CREATE=10, CHANNEL_DELETE=12, CHANNEL_UPDATE=11
-		if entry.TargetID != "" {
-			// Check if the channel actually exists
-			_, err := sess.Channel(entry.TargetID)
-			if err != nil {
-				isFakeEvent = true
-				eventTypeName = map[int]string{10: "channel create", 12: "channel delete", 11: "channel update"}[actionType]
-			}
-		}
+	entry = auditMatcher.MatchEvent(eventTime.UnixNano(), entries, targetID)
+	if entry == nil {
+		return nil, false, nil
 	}
 
-	// Check for fake webhook events
-	if actionType == 50 || actionType == 51 || actionType == 52 { // WEBHOOK_CREATE=50, WEBHOOK_UPDATE=51, WEBHOOK_DELETE=52
-		if entry.TargetID != "" {
-			// Webhooks are harder to verify, but we can try to fetch it
-			// For now, we'll log but not auto-ban (webhooks get deleted legitimately)
-			// Only flag as fake if it's a webhook create/update for non-existent webhook
-			if actionType == 50 || actionType == 51 {
-				// Try to get webhook (will fail if doesn't exist)
-				_, err := sess.Webhook(entry.TargetID)
-				if err != nil {
-					isFakeEvent = true
-					eventTypeName = map[int]string{50: "webhook create", 51: "webhook update", 52: "webhook delete"}[actionType]
-				}
-			}
+	for _, user := range audit.Users {
+		if user.ID == entry.UserID && user.Bot {
+			return entry, true, nil
 		}
 	}
+	return entry, false, nil
+}
 
-	// If fake event detected, ban the perpetrator
-	if isFakeEvent {
-		logging.Warn("[FAKE EVENT DETECTED] %s (action %d) on non-existent target %s by %s in guild %s",
-			eventTypeName, actionType, entry.TargetID, entry.UserID, guildID)
+// joinAuditEntry pairs an event with the GUILD_AUDIT_LOG_ENTRY_CREATE about
+// the same target, whichever of the two reaches us first. Only when the entry
+// has not arrived within attributionWait is the audit log fetched over REST.
+// Actions by bots resolve to actor 0; found is false when no entry was found.
+func joinAuditEntry(sess *discordgo.Session, guildID string, actionType int, targetID uint64) (actorID uint64, found bool, err error) {
+	eventTime := time.Now()
 
-		// Ban the perpetrator immediately
-		go func() {
-			err := sess.GuildBanCreateWithReason(guildID, entry.UserID,
-				fmt.Sprintf("Fake %s event detected - security violation", eventTypeName), 0)
-			if err != nil {
-				logging.Error("Failed to ban fake event perpetrator %s: %v", entry.UserID, err)
-			} else {
-				logging.Info("[✓ FAKE EVENT BAN] Banned %s for creating fake %s event", entry.UserID, eventTypeName)
-
-				// Add to database
-				if db := database.GetDB(); db != nil {
-					db.AddBannedUser(guildID, entry.UserID,
-						fmt.Sprintf("Fake %s event creator", eventTypeName),
-						"antinuke-bot", false, "")
-				}
+	if actorID, found := auditCache.Await(guildID, actionType, targetID, attributionWait); found {
+		return actorID, true, nil
+	}
 
-				// Send log to guild's log channel
-				guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
-				_ = guildIDNum // Use database for log channel
-				if db := database.GetDB(); db != nil {
-					guildConfig, err := db.GetGuildConfig(guildID)
-					if err == nil && guildConfig != nil && guildConfig.LogChannelID != "" {
-						sess.ChannelMessageSendEmbed(guildConfig.LogChannelID, &discordgo.MessageEmbed{
-							Title: "🚨 FAKE EVENT DETECTED & BANNED",
-							Description: fmt.Sprintf("User <@%s> created a fake **%s** event targeting non-existent target `%s`\n\n**Action Taken:** Permanently banned\n\n**Reason:** Creating fake audit log events is a serious security violation.",
-								entry.UserID,
-								eventTypeName,
-								entry.TargetID),
-							Color:     0xFF0000,
-							Timestamp: time.Now().Format(time.RFC3339),
-							Footer: &discordgo.MessageEmbedFooter{
-								Text: "Antinuke Security System",
-							},
-						})
-					}
-				}
-			}
-		}()
+	entry, isBot, err := matchAuditEntry(sess, guildID, actionType, targetID, eventTime)
+	if err != nil {
+		return 0, false, err
+	}
+	if entry == nil {
+		logging.Debug("[AUDIT] No audit entry names target %d for action %d in guild %s, leaving it unattributed",
+			targetID, actionType, guildID)
+		return 0, false, nil
+	}
 
-		return 0 // Don't process this fake event further
+	// Skip actions by bots, including our own punishments and reverts
+	if !isBot {
+		actorID, _ = strconv.ParseUint(entry.UserID, 10, 64)
 	}
+	auditCache.Store(guildID, actionType, actorID, targetID)
+	return actorID, true, nil
+}
 
-	// Check if the actor is a bot - skip bot actions entirely
-	if len(audit.Users) > 0 {
-		for _, user := range audit.Users {
-			if user.ID == entry.UserID && user.Bot {
-				logging.Debug("[AUDIT] Skipping action %d by bot user %s", actionType, user.Username)
-				return 0
-			}
-		}
+// isBotUser reports whether userID is us or another bot, from gateway state only.
+func isBotUser(sess *discordgo.Session, guildID, userID string) bool {
+	if sess.State.User != nil && sess.State.User.ID == userID {
+		return true
 	}
+	member, err := sess.State.Member(guildID, userID)
+	return err == nil && member.User != nil && member.User.Bot
+}
 
-	actorID, _ := strconv.ParseUint(entry.UserID, 10, 64)
+// targetMatchWindow bounds how far an audit entry's creation may lie from the event it explains
+const targetMatchWindow = 15 * time.Second
 
-	// Cache it for future use
-	auditCache.Store(guildID, actionType, actorID, targetID)
+// fetchActorForTarget returns who performed actionType on targetID, or 0 when
+// no recent entry names that target.
+func fetchActorForTarget(sess *discordgo.Session, guildID string, actionType int, targetID uint64) uint64 {
+	actorID, _ := resolveActorForTarget(sess, guildID, actionType, targetID)
+	return actorID
+}
 
+// resolveActorForTarget is fetchActorForTarget that also reports whether an
+// entry was found, telling a bot's action (actor 0, found) apart from one
+// whose actor is unknown.
+func resolveActorForTarget(sess *discordgo.Session, guildID string, actionType int, targetID uint64) (uint64, bool) {
+	actorID, found, err := joinAuditEntry(sess, guildID, actionType, targetID)
+	if err != nil {
+		logging.Warn("Failed to fetch audit log for guild %s action %d: %v", guildID, actionType, err)
+		return 0, false
+	}
+	return actorID, found
+}
+
+// awaitActorForTarget is fetchActorForTarget without the REST fallback. It is
+// only for member removals, which are mostly self-leaves: a kick entry that
+// has not arrived within attributionWait almost always does not exist, and a
+// REST lookup per leave would eat the audit log rate limit.
+func awaitActorForTarget(guildID string, actionType int, targetID uint64) uint64 {
+	actorID, _ := auditCache.Await(guildID, actionType, targetID, attributionWait)
 	return actorID
 }
 
@@ -227,8 +237,17 @@ func fetchActorFromAuditLog(sess *discordgo.Session, guildID string, actionType
 func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 	logging.Info("Setting up Discord event handlers...")
 
+	// Members missing from the role cache are resolved from the gateway state, never over REST
+	state.GetMemberRoleCache().SetResolver(func(guildID, userID uint64) ([]uint64, bool) {
+		member, err := s.ShardFor(guildID).State.Member(strconv.FormatUint(guildID, 10), strconv.FormatUint(userID, 10))
+		if err != nil {
+			return nil, false
+		}
+		return parseRoleIDs(member.Roles), true
+	})
+
 	// Handle bot joining new guilds - auto-initialize with all events enabled
-	s.discord.AddHandler(func(sess *discordgo.Session, g *discordgo.GuildCreate) {
+	s.AddHandler(func(sess *discordgo.Session, g *discordgo.GuildCreate) {
 		logging.Info("Bot joined/loaded guild: %s (ID: %s)", g.Name, g.ID)
 
 		// Clear all actor state for this guild when bot is re-added
@@ -237,6 +256,39 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		state.ClearGuildActorStates(guildID)
 		logging.Info("✓ Cleared actor state for guild %s", g.ID)
 
+		// Seed the member role cache from the members Discord sent with the guild
+		roleCache := state.GetMemberRoleCache()
+		for _, member := range g.Members {
+			if member.User == nil {
+				continue
+			}
+			userID, _ := strconv.ParseUint(member.User.ID, 10, 64)
+			roleCache.Set(guildID, userID, parseRoleIDs(member.Roles))
+		}
+
+		// Seed the role permission cache so the first escalation has a baseline
+		seedRolePermissions(ringBuffer, guildID, g.Roles)
+
+		// Same for the guild settings GUILD_UPDATE is diffed against
+		state.GetGuildSettingsCache().Update(guildID, guildSettingsFrom(g.Guild))
+
+		// And for the emoji and sticker lists, whose images are backed up to disk
+		assetCache := state.GetGuildAssetCache()
+		assetCache.Update(guildID, state.AssetEmoji, emojiAssets(g.Emojis))
+		assetCache.Update(guildID, state.AssetSticker, stickerAssets(g.Stickers))
+		go func() {
+			backupEmojis(sess, guildID, g.Emojis)
+			backupStickers(sess, guildID, g.Stickers)
+		}()
+
+		// AutoMod rules are restored from these definitions if disabled or deleted
+		go seedAutoModRules(sess, g.ID)
+
+		// Baseline for our own role, every ban depends on it
+		if status, ok := selfRoleStatus(sess, g.ID); ok {
+			state.GetSelfRoleCache().Update(guildID, status)
+		}
+
 		// Store owner ID in guild profile
 		ownerID, _ := strconv.ParseUint(g.OwnerID, 10, 64)
 		profile := config.GetProfileStore().GetOrCreate(guildID)
@@ -248,8 +300,266 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		// This is handled by EnsureGuildConfigExists in the sync process
 	})
 
+	// Handle Guild Update - diff critical settings and report who changed them
+	s.AddHandler(func(sess *discordgo.Session, g *discordgo.GuildUpdate) {
+		startTime := time.Now()
+
+		if g.Guild == nil || g.ID == "" {
+			return
+		}
+
+		guildID, _ := strconv.ParseUint(g.ID, 10, 64)
+		settingsCache := state.GetGuildSettingsCache()
+		before, changed, known := settingsCache.Update(guildID, guildSettingsFrom(g.Guild))
+		if !known || changed == 0 {
+			return
+		}
+
+		actorID := fetchActorForTarget(sess, g.ID, 1, guildID) // 1 = GUILD_UPDATE
+		if actorID == 0 {
+			return
+		}
+
+		// Keep the before-image so the dispatcher can restore the changed fields
+		settingsCache.RecordRevert(guildID, actorID, before, changed)
+
+		// Metadata carries the mask of changed fields
+		event := ingest.CreateEvent(
+			ingest.EventTypeServerUpdate,
+			guildID,
+			actorID,
+			guildID,
+			changed,
+		)
+		ringBuffer.Enqueue(event)
+
+		latencyUs := time.Since(startTime).Microseconds()
+		logging.Info("[EVENT] Guild settings update: fields %#x by actor %d | Latency: %d µs", changed, actorID, latencyUs)
+	})
+
+	// Handle Guild Emojis Update - diff the full list Discord sends and attribute each change
+	s.AddHandler(func(sess *discordgo.Session, e *discordgo.GuildEmojisUpdate) {
+		if e.GuildID == "" {
+			return
+		}
+
+		guildID, _ := strconv.ParseUint(e.GuildID, 10, 64)
+		diff, known := state.GetGuildAssetCache().Update(guildID, state.AssetEmoji, emojiAssets(e.Emojis))
+		if len(diff.Created) > 0 || len(diff.Updated) > 0 {
+			go backupEmojis(sess, guildID, e.Emojis)
+		}
+		if !known {
+			return
+		}
+
+		enqueueAssetChanges(sess, ringBuffer, e.GuildID, forensics.AssetKindEmoji, diff, [3]int{60, 61, 62}) // EMOJI_CREATE, EMOJI_UPDATE, EMOJI_DELETE
+	})
+
+	// Handle Guild Stickers Update - same as emojis, with the sticker audit actions
+	s.AddHandler(func(sess *discordgo.Session, e *discordgo.GuildStickersUpdate) {
+		if e.GuildID == "" {
+			return
+		}
+
+		guildID, _ := strconv.ParseUint(e.GuildID, 10, 64)
+		diff, known := state.GetGuildAssetCache().Update(guildID, state.AssetSticker, stickerAssets(e.Stickers))
+		if len(diff.Created) > 0 || len(diff.Updated) > 0 {
+			go backupStickers(sess, guildID, e.Stickers)
+		}
+		if !known {
+			return
+		}
+
+		enqueueAssetChanges(sess, ringBuffer, e.GuildID, forensics.AssetKindSticker, diff, [3]int{90, 91, 92}) // STICKER_CREATE, STICKER_UPDATE, STICKER_DELETE
+	})
+
+	// Handle AutoMod Rule Create - cache the definition and count creations
+	s.AddHandler(func(sess *discordgo.Session, r *discordgo.AutoModerationRuleCreate) {
+		startTime := time.Now()
+		ruleID, definition, ok := autoModDefinition(r.AutoModerationRule)
+		if !ok {
+			return
+		}
+		guildID, _ := strconv.ParseUint(r.GuildID, 10, 64)
+		state.GetAutoModRuleCache().Set(guildID, ruleID, definition)
+
+		actorID := fetchActorForTarget(sess, r.GuildID, 140, ruleID) // 140 = AUTO_MODERATION_RULE_CREATE
+		if actorID == 0 {
+			return
+		}
+		enqueueAutoModChange(ringBuffer, ingest.EventTypeAutomodRuleCreate, guildID, actorID, ruleID, false)
+
+		logging.Info("[EVENT] AutoMod rule create: %s by actor %d | Latency: %d µs", r.Name, actorID, time.Since(startTime).Microseconds())
+	})
+
+	// Handle AutoMod Rule Update - a rule switched off is restored from its cached definition
+	s.AddHandler(func(sess *discordgo.Session, r *discordgo.AutoModerationRuleUpdate) {
+		startTime := time.Now()
+		ruleID, definition, ok := autoModDefinition(r.AutoModerationRule)
+		if !ok {
+			return
+		}
+		guildID, _ := strconv.ParseUint(r.GuildID, 10, 64)
+		cache := state.GetAutoModRuleCache()
+		before, known := cache.Set(guildID, ruleID, definition)
+
+		actorID := fetchActorForTarget(sess, r.GuildID, 141, ruleID) // 141 = AUTO_MODERATION_RULE_UPDATE
+		if actorID == 0 {
+			return
+		}
+
+		// Keep the enabled definition so the dispatcher can switch the rule back on
+		disabled := known && autoModRuleEnabled(before) && !autoModRuleEnabled(definition)
+		if disabled {
+			cache.RecordRevert(guildID, ruleID, before, false)
+		}
+		enqueueAutoModChange(ringBuffer, ingest.EventTypeAutomodRuleUpdate, guildID, actorID, ruleID, disabled)
+
+		logging.Info("[EVENT] AutoMod rule update: %s (disabled: %v) by actor %d | Latency: %d µs", r.Name, disabled, actorID, time.Since(startTime).Microseconds())
+	})
+
+	// Handle AutoMod Rule Delete - the payload is the full rule, so it can always be recreated
+	s.AddHandler(func(sess *discordgo.Session, r *discordgo.AutoModerationRuleDelete) {
+		startTime := time.Now()
+		ruleID, definition, ok := autoModDefinition(r.AutoModerationRule)
+		if !ok {
+			return
+		}
+		guildID, _ := strconv.ParseUint(r.GuildID, 10, 64)
+		cache := state.GetAutoModRuleCache()
+		cache.Remove(guildID, ruleID)
+
+		actorID := fetchActorForTarget(sess, r.GuildID, 142, ruleID) // 142 = AUTO_MODERATION_RULE_DELETE
+		if actorID == 0 {
+			return
+		}
+
+		cache.RecordRevert(guildID, ruleID, definition, true)
+		enqueueAutoModChange(ringBuffer, ingest.EventTypeAutomodRuleDelete, guildID, actorID, ruleID, true)
+
+		logging.Info("[EVENT] AutoMod rule delete: %s by actor %d | Latency: %d µs", r.Name, actorID, time.Since(startTime).Microseconds())
+	})
+
+	// Handle Scheduled Event Create - every event notifies the guild, so creations are tracked for cleanup
+	s.AddHandler(func(sess *discordgo.Session, e *discordgo.GuildScheduledEventCreate) {
+		if e.GuildScheduledEvent == nil || e.GuildID == "" {
+			return
+		}
+		startTime := time.Now()
+		guildID, _ := strconv.ParseUint(e.GuildID, 10, 64)
+		eventID, _ := strconv.ParseUint(e.ID, 10, 64)
+
+		actorID := fetchActorForTarget(sess, e.GuildID, 100, eventID) // 100 = GUILD_SCHEDULED_EVENT_CREATE
+		if actorID == 0 {
+			return
+		}
+		state.GetScheduledEventRegistry().Track(guildID, actorID, eventID)
+
+		event := ingest.CreateEvent(
+			ingest.EventTypeGuildEventCreate,
+			guildID,
+			actorID,
+			eventID,
+			0,
+		)
+		ringBuffer.Enqueue(event)
+
+		logging.Info("[EVENT] Scheduled event create: %s by actor %d | Latency: %d µs", e.Name, actorID, time.Since(startTime).Microseconds())
+	})
+
+	// Handle Scheduled Event Update
+	s.AddHandler(func(sess *discordgo.Session, e *discordgo.GuildScheduledEventUpdate) {
+		if e.GuildScheduledEvent == nil || e.GuildID == "" {
+			return
+		}
+		startTime := time.Now()
+		guildID, _ := strconv.ParseUint(e.GuildID, 10, 64)
+		eventID, _ := strconv.ParseUint(e.ID, 10, 64)
+
+		// Automatic status changes have no audit entry and are skipped
+		actorID := fetchActorForTarget(sess, e.GuildID, 101, eventID) // 101 = GUILD_SCHEDULED_EVENT_UPDATE
+		if actorID == 0 {
+			return
+		}
+
+		event := ingest.CreateEvent(
+			ingest.EventTypeGuildEventUpdate,
+			guildID,
+			actorID,
+			eventID,
+			0,
+		)
+		ringBuffer.Enqueue(event)
+
+		logging.Info("[EVENT] Scheduled event update: %s by actor %d | Latency: %d µs", e.Name, actorID, time.Since(startTime).Microseconds())
+	})
+
+	// Handle Scheduled Event Delete
+	s.AddHandler(func(sess *discordgo.Session, e *discordgo.GuildScheduledEventDelete) {
+		if e.GuildScheduledEvent == nil || e.GuildID == "" {
+			return
+		}
+		startTime := time.Now()
+		guildID, _ := strconv.ParseUint(e.GuildID, 10, 64)
+		eventID, _ := strconv.ParseUint(e.ID, 10, 64)
+		state.GetScheduledEventRegistry().Forget(eventID)
+
+		actorID := fetchActorForTarget(sess, e.GuildID, 102, eventID) // 102 = GUILD_SCHEDULED_EVENT_DELETE
+		if actorID == 0 {
+			return
+		}
+
+		event := ingest.CreateEvent(
+			ingest.EventTypeGuildEventDelete,
+			guildID,
+			actorID,
+			eventID,
+			0,
+		)
+		ringBuffer.Enqueue(event)
+
+		logging.Info("[EVENT] Scheduled event delete: %s by actor %d | Latency: %d µs", e.Name, actorID, time.Since(startTime).Microseconds())
+	})
+
+	// Handle Message Create - count @everyone, @here and role pings; ordinary messages return at once
+	s.AddHandler(func(sess *discordgo.Session, m *discordgo.MessageCreate) {
+		if m.GuildID == "" || m.Author == nil || (!m.MentionEveryone && len(m.MentionRoles) == 0) {
+			return
+		}
+		startTime := time.Now()
+
+		guildID, _ := strconv.ParseUint(m.GuildID, 10, 64)
+		channelID, _ := strconv.ParseUint(m.ChannelID, 10, 64)
+		messageID, _ := strconv.ParseUint(m.ID, 10, 64)
+		actorID := pingActor(sess, guildID, m.Message)
+		if actorID == 0 || actorID == state.GetBotID() {
+			return
+		}
+
+		eventType := uint8(ingest.EventTypeRolePing)
+		if m.MentionEveryone {
+			eventType = ingest.EventTypeEveryoneHerePing
+		}
+
+		// Logged before the event so the purge job finds the message that tipped the limit
+		state.GetPingLog().Record(guildID, actorID, channelID, messageID)
+
+		// Metadata carries the number of roles pinged
+		event := ingest.CreateEvent(
+			eventType,
+			guildID,
+			actorID,
+			messageID,
+			uint64(len(m.MentionRoles)),
+		)
+		ringBuffer.Enqueue(event)
+
+		logging.Info("[EVENT] Mass ping: everyone=%v roles=%d by actor %d (webhook %q) | Latency: %d µs",
+			m.MentionEveryone, len(m.MentionRoles), actorID, m.WebhookID, time.Since(startTime).Microseconds())
+	})
+
 	// Handle bot ready - clear state for all guilds
-	s.discord.AddHandler(func(sess *discordgo.Session, r *discordgo.Ready) {
+	s.AddHandler(func(sess *discordgo.Session, r *discordgo.Ready) {
 		fmt.Printf("[BOT] Ready event fired! Connected as %s\n", r.User.Username)
 		fmt.Printf("[BOT] Clearing actor state for %d guilds...\n", len(r.Guilds))
 		logging.Info("Bot ready! Connected as %s", r.User.Username)
@@ -265,24 +575,163 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		fmt.Printf("[BOT] State clearing complete!\n")
 	})
 
+	// Handle Guild Ban Add - attribute each ban to its executor for mass-ban detection
+	s.AddHandler(func(sess *discordgo.Session, b *discordgo.GuildBanAdd) {
+		startTime := time.Now()
+
+		if b.GuildID == "" || b.User == nil {
+			return
+		}
+
+		guildID, _ := strconv.ParseUint(b.GuildID, 10, 64)
+		targetID, _ := strconv.ParseUint(b.User.ID, 10, 64)
+
+		if ingestedByGateway(sess, 22) {
+			return
+		}
+
+		actorID := fetchActorForTarget(sess, b.GuildID, 22, targetID) // 22 = MEMBER_BAN_ADD
+
+		if actorID == 0 {
+			logging.Warn("[EVENT] Ban but no actor ID: %s", b.User.ID)
+			return
+		}
+
+		event := ingest.CreateEvent(
+			ingest.EventTypeBan,
+			guildID,
+			actorID,
+			targetID,
+			0,
+		)
+		ringBuffer.Enqueue(event)
+
+		latencyUs := time.Since(startTime).Microseconds()
+		logging.Info("[EVENT] Ban: %s by actor %d | Latency: %d µs", b.User.ID, actorID, latencyUs)
+	})
+
 	// Handle Guild Ban Remove (Unban) - Clear actor state so they can be detected again if they return
-	s.discord.AddHandler(func(sess *discordgo.Session, b *discordgo.GuildBanRemove) {
+	s.AddHandler(func(sess *discordgo.Session, b *discordgo.GuildBanRemove) {
 		if b.GuildID == "" {
 			return
 		}
 		actorID, _ := strconv.ParseUint(b.User.ID, 10, 64)
-		state.ClearActorState(actorID)
+		guildID, _ := strconv.ParseUint(b.GuildID, 10, 64)
+		state.ClearActorState(guildID, actorID)
 		logging.Info("[STATE] Cleared actor state for unbanned user %s in guild %s", b.User.ID, b.GuildID)
 	})
 
+	// Keep the member role cache current for role whitelist checks
+	s.AddHandler(func(sess *discordgo.Session, c *discordgo.GuildMembersChunk) {
+		guildID, _ := strconv.ParseUint(c.GuildID, 10, 64)
+		roleCache := state.GetMemberRoleCache()
+		for _, member := range c.Members {
+			if member.User == nil {
+				continue
+			}
+			userID, _ := strconv.ParseUint(member.User.ID, 10, 64)
+			roleCache.Set(guildID, userID, parseRoleIDs(member.Roles))
+		}
+	})
+
+	// Handle Guild Member Update - keep cached roles current and report dangerous role grants
+	s.AddHandler(func(sess *discordgo.Session, m *discordgo.GuildMemberUpdate) {
+		if m.GuildID == "" || m.Member == nil || m.User == nil {
+			return
+		}
+		startTime := time.Now()
+		guildID, _ := strconv.ParseUint(m.GuildID, 10, 64)
+		userID, _ := strconv.ParseUint(m.User.ID, 10, 64)
+
+		// The session state is already updated when handlers run, so diff
+		// against our own cache first and the state's before-image second
+		roleCache := state.GetMemberRoleCache()
+		before, known := roleCache.Cached(guildID, userID)
+		if !known && m.BeforeUpdate != nil {
+			before, known = parseRoleIDs(m.BeforeUpdate.Roles), true
+		}
+		after := parseRoleIDs(m.Roles)
+		roleCache.Set(guildID, userID, after)
+
+		// Roles taken from the bot itself can cost it ban-critical permissions
+		if userID == state.GetBotID() {
+			checkSelfRole(sess, ringBuffer, m.GuildID, 25, userID) // 25 = MEMBER_ROLE_UPDATE
+			return
+		}
+		if !known {
+			return
+		}
+
+		granted := dangerousRoleGrants(sess, m.GuildID, before, after)
+		if len(granted) == 0 {
+			return
+		}
+
+		actorID := fetchActorForTarget(sess, m.GuildID, 25, userID) // 25 = MEMBER_ROLE_UPDATE
+		if actorID == 0 {
+			return
+		}
+
+		for _, grant := range granted {
+			event := ingest.CreateEvent(
+				ingest.EventTypeMemberUpdate,
+				guildID,
+				actorID,
+				userID,
+				grant.roleID,
+			)
+			if grant.perms&detectors.PermAdministrator != 0 {
+				event.Flags |= ingest.EventFlagAdminGrant
+			}
+			ringBuffer.Enqueue(event)
+		}
+
+		latencyUs := time.Since(startTime).Microseconds()
+		logging.Info("[EVENT] Dangerous role grant: %d role(s) to %s by actor %d | Latency: %d µs", len(granted), m.User.ID, actorID, latencyUs)
+	})
+
+	// Handle Guild Member Remove - drop cached roles and feed kicks to mass-kick detection
+	s.AddHandler(func(sess *discordgo.Session, m *discordgo.GuildMemberRemove) {
+		if m.GuildID == "" || m.Member == nil || m.User == nil {
+			return
+		}
+		startTime := time.Now()
+		guildID, _ := strconv.ParseUint(m.GuildID, 10, 64)
+		userID, _ := strconv.ParseUint(m.User.ID, 10, 64)
+		state.GetMemberRoleCache().Remove(guildID, userID)
+
+		if ingestedByGateway(sess, 20) {
+			return
+		}
+
+		// Only removals backed by a MEMBER_KICK entry count, self-leaves are ignored
+		actorID := awaitActorForTarget(m.GuildID, 20, userID) // 20 = MEMBER_KICK
+		if actorID == 0 {
+			return
+		}
+
+		event := ingest.CreateEvent(
+			ingest.EventTypeKick,
+			guildID,
+			actorID,
+			userID,
+			0,
+		)
+		ringBuffer.Enqueue(event)
+
+		latencyUs := time.Since(startTime).Microseconds()
+		logging.Info("[EVENT] Kick: %s by actor %d | Latency: %d µs", m.User.ID, actorID, latencyUs)
+	})
+
 	// Handle Guild Member Add (Join) - Panic mode rejoin logic
-	s.discord.AddHandler(func(sess *discordgo.Session, m *discordgo.GuildMemberAdd) {
+	s.AddHandler(func(sess *discordgo.Session, m *discordgo.GuildMemberAdd) {
 		if m.GuildID == "" {
 			return
 		}
 
 		userID, _ := strconv.ParseUint(m.User.ID, 10, 64)
 		guildID, _ := strconv.ParseUint(m.GuildID, 10, 64)
+		state.GetMemberRoleCache().Set(guildID, userID, parseRoleIDs(m.Roles))
 
 		// Check if this is a bot joining
 		if m.User.Bot {
@@ -322,7 +771,7 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 			// Get guild profile to check owner and whitelist
 			profile := config.GetProfileStore().Get(guildID)
 			isOwner := profile != nil && profile.OwnerID == adderIDNum
-			isWhitelisted := profile != nil && config.GetProfileStore().IsWhitelisted(guildID, adderIDNum)
+			isWhitelisted := profile != nil && config.GetProfileStore().IsWhitelisted(guildID, adderIDNum, ingest.EventTypeBot)
 
 			if isOwner || isWhitelisted {
 				// Owner or whitelisted user added the bot - ALLOW IT
@@ -335,7 +784,7 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 				if db := database.GetDB(); db != nil {
 					db.RemoveBannedUser(m.GuildID, m.User.ID)
 				}
-				state.ClearActorState(userID)
+				state.ClearActorState(guildID, userID)
 
 				// Log this action
 				logging.Info("[STATE] Bot %s allowed and given fresh start by %s", m.User.ID, adderID)
@@ -392,10 +841,40 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		}
 
 		// Clear actor state for fresh start - they can be banned again if they violate
-		state.ClearActorState(userID)
+		state.ClearActorState(guildID, userID)
 		logging.Info("[✓ FRESH START] User %s given clean slate in guild %s - tracking reset", m.User.ID, m.GuildID)
-	}) // CRITICAL: GuildAuditLogEntryCreate - This captures WHO did the action
-	s.discord.AddHandler(func(sess *discordgo.Session, audit *discordgo.GuildAuditLogEntryCreate) {
+	})
+
+	// Handle Integration Create - apps and integrations added without a bot joining
+	s.AddHandler(func(sess *discordgo.Session, i *discordgo.IntegrationCreate) {
+		if i.GuildID == "" || i.Integration == nil {
+			return
+		}
+
+		// The integration names the user who added it; fall back to the audit log
+		adderID := ""
+		if i.User != nil && !i.User.Bot {
+			adderID = i.User.ID
+		} else if integrationID, err := strconv.ParseUint(i.ID, 10, 64); err == nil {
+			if actorID := fetchActorForTarget(sess, i.GuildID, 80, integrationID); actorID != 0 { // 80 = INTEGRATION_CREATE
+				adderID = strconv.FormatUint(actorID, 10)
+			}
+		}
+
+		logging.Info("[INTEGRATION ADDED] %s integration %s (%s) in guild %s", i.Type, i.Name, i.ID, i.GuildID)
+		go guardIntegration(sess, i.GuildID, i.ID, i.Name, i.Type, adderID)
+	})
+
+	// Handle Guild Integrations Update - only names the guild, catches additions INTEGRATION_CREATE missed
+	s.AddHandler(func(sess *discordgo.Session, u *discordgo.GuildIntegrationsUpdate) {
+		if u.GuildID == "" {
+			return
+		}
+		go checkRecentIntegrations(sess, u.GuildID)
+	})
+
+	// CRITICAL: GuildAuditLogEntryCreate - This captures WHO did the action
+	s.AddHandler(func(sess *discordgo.Session, audit *discordgo.GuildAuditLogEntryCreate) {
 		startTime := time.Now() // Track detection latency
 
 		if audit.GuildID == "" {
@@ -414,8 +893,95 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 			actionType = int(*audit.ActionType)
 		}
 
-		// Store in cache for correlation with direct events
-		auditCache.Store(audit.GuildID, actionType, actorID, targetID)
+		// Store in cache for correlation with direct events. Bot actions,
+		// including our own punishments and reverts, are joined as unattributed
+		// and the audit-only actions below skip them
+		cachedActor := actorID
+		if isBotUser(sess, audit.GuildID, audit.UserID) {
+			cachedActor = 0
+		}
+		auditCache.Store(audit.GuildID, actionType, cachedActor, targetID)
+
+		// Entries about channels and roles must agree with what the gateway saw
+		scheduleFakeEventCheck(sess, audit.GuildID, actionType, audit.UserID, audit.TargetID)
+
+		// Some actions have no usable gateway event, the audit entry is the only signal
+		switch actionType {
+		case 21: // MEMBER_PRUNE
+			if cachedActor == 0 {
+				break
+			}
+			membersRemoved := uint64(0)
+			if audit.Options != nil {
+				membersRemoved, _ = strconv.ParseUint(audit.Options.MembersRemoved, 10, 64)
+			}
+
+			guildID, _ := strconv.ParseUint(audit.GuildID, 10, 64)
+			event := ingest.CreateEvent(
+				ingest.EventTypeMemberPrune,
+				guildID,
+				cachedActor,
+				0,
+				membersRemoved,
+			)
+			ringBuffer.Enqueue(event)
+
+			logging.Info("[EVENT] Member prune: %d members by actor %d | Latency: %d µs",
+				membersRemoved, cachedActor, time.Since(startTime).Microseconds())
+
+		case 50, 51: // WEBHOOK_CREATE, WEBHOOK_UPDATE (WEBHOOKS_UPDATE only names the channel)
+			if cachedActor == 0 {
+				break
+			}
+			guildID, _ := strconv.ParseUint(audit.GuildID, 10, 64)
+			if actionType == 50 {
+				state.GetWebhookRegistry().Track(guildID, cachedActor, targetID)
+			}
+
+			event := ingest.CreateEvent(
+				ingest.EventTypeWebhook,
+				guildID,
+				cachedActor,
+				targetID,
+				0,
+			)
+			ringBuffer.Enqueue(event)
+
+			logging.Info("[EVENT] Webhook %s: %d by actor %d | Latency: %d µs",
+				map[int]string{50: "create", 51: "update"}[actionType], targetID, cachedActor, time.Since(startTime).Microseconds())
+
+		case 52: // WEBHOOK_DELETE
+			state.GetWebhookRegistry().Forget(targetID)
+
+		case 13, 14, 15: // CHANNEL_OVERWRITE_CREATE, CHANNEL_OVERWRITE_UPDATE, CHANNEL_OVERWRITE_DELETE
+			if audit.Options == nil || cachedActor == 0 {
+				break
+			}
+			guildID, _ := strconv.ParseUint(audit.GuildID, 10, 64)
+			image := overwriteBeforeImage(sess, audit.AuditLogEntry, actionType, targetID)
+			_, newAllow, _ := auditPermissionChange(audit.Changes, discordgo.AuditLogChangeKeyAllow)
+			if actionType == 15 {
+				newAllow = 0
+			}
+			added := newAllow &^ image.Allow
+
+			// Metadata carries the allow bits the change added
+			event := ingest.CreateEvent(
+				ingest.EventTypeChannelOverwrite,
+				guildID,
+				cachedActor,
+				targetID,
+				added,
+			)
+			if added&detectors.CriticalOverwriteMask != 0 && isBroadOverwriteTarget(guildID, image) {
+				event.Flags |= ingest.EventFlagCriticalOverwrite
+				state.GetOverwriteRegistry().Record(guildID, cachedActor, image)
+			}
+			ringBuffer.Enqueue(event)
+
+			logging.Info("[EVENT] Channel overwrite %s: %s on channel %d by actor %d | Latency: %d µs",
+				map[int]string{13: "create", 14: "update", 15: "delete"}[actionType], audit.Options.ID, targetID, cachedActor, time.Since(startTime).Microseconds())
+		}
 
 		logging.Debug("[AUDIT] Action %d by user %d in guild %s | Latency: %d µs",
 			actionType, actorID, audit.GuildID, time.Since(startTime).Microseconds())
@@ -424,7 +990,7 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 	// DIRECT EVENT HANDLERS - These fire immediately, we correlate with audit logs for actor ID
 
 	// Channel Create - Fetch audit logs immediately to get actor
-	s.discord.AddHandler(func(sess *discordgo.Session, c *discordgo.ChannelCreate) {
+	s.AddHandler(func(sess *discordgo.Session, c *discordgo.ChannelCreate) {
 		startTime := time.Now()
 
 		if c.GuildID == "" {
@@ -434,8 +1000,12 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		guildID, _ := strconv.ParseUint(c.GuildID, 10, 64)
 		channelIDNum, _ := strconv.ParseUint(c.ID, 10, 64)
 
+		if ingestedByGateway(sess, 10) {
+			return
+		}
+
 		// Fetch audit log entry for this specific action
-		actorID := fetchActorFromAuditLog(sess, c.GuildID, 10, channelIDNum) // 10 = CHANNEL_CREATE
+		actorID := fetchActorForTarget(sess, c.GuildID, 10, channelIDNum) // 10 = CHANNEL_CREATE
 
 		if actorID == 0 {
 			logging.Warn("[EVENT] Channel create but no actor ID: %s", c.ID)
@@ -456,7 +1026,7 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 	})
 
 	// Channel Delete
-	s.discord.AddHandler(func(sess *discordgo.Session, c *discordgo.ChannelDelete) {
+	s.AddHandler(func(sess *discordgo.Session, c *discordgo.ChannelDelete) {
 		startTime := time.Now()
 
 		if c.GuildID == "" {
@@ -466,7 +1036,14 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		guildID, _ := strconv.ParseUint(c.GuildID, 10, 64)
 		channelIDNum, _ := strconv.ParseUint(c.ID, 10, 64)
 
-		actorID := fetchActorFromAuditLog(sess, c.GuildID, 12, channelIDNum) // 12 = CHANNEL_DELETE
+		// The state cache has already forgotten the channel, remember the delete
+		state.GetRecentDeletes().Record(guildID, channelIDNum)
+
+		if ingestedByGateway(sess, 12) {
+			return
+		}
+
+		actorID := fetchActorForTarget(sess, c.GuildID, 12, channelIDNum) // 12 = CHANNEL_DELETE
 
 		if actorID == 0 {
 			logging.Warn("[EVENT] Channel delete but no actor ID: %s", c.ID)
@@ -487,13 +1064,19 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 	})
 
 	// Role Create
-	s.discord.AddHandler(func(sess *discordgo.Session, r *discordgo.GuildRoleCreate) {
+	s.AddHandler(func(sess *discordgo.Session, r *discordgo.GuildRoleCreate) {
 		startTime := time.Now()
 
 		if r.GuildID == "" {
 			return
 		}
 
+		guildID, _ := strconv.ParseUint(r.GuildID, 10, 64)
+		roleIDNum, _ := strconv.ParseUint(r.Role.ID, 10, 64)
+
+		// New roles need a permission baseline too, managed ones included
+		seedRolePermissions(ringBuffer, guildID, []*discordgo.Role{r.Role})
+
 		// Skip managed roles (bot roles, integration roles, booster roles, etc.)
 		// These are created automatically by Discord and are not malicious
 		if r.Role.Managed {
@@ -501,10 +1084,7 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 			return
 		}
 
-		guildID, _ := strconv.ParseUint(r.GuildID, 10, 64)
-		roleIDNum, _ := strconv.ParseUint(r.Role.ID, 10, 64)
-
-		actorID := fetchActorFromAuditLog(sess, r.GuildID, 30, roleIDNum) // 30 = ROLE_CREATE
+		actorID := fetchActorForTarget(sess, r.GuildID, 30, roleIDNum) // 30 = ROLE_CREATE
 
 		if actorID == 0 {
 			logging.Warn("[EVENT] Role create but no actor ID: %s", r.Role.ID)
@@ -524,8 +1104,49 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		logging.Info("[EVENT] Role create: %s by actor %d | Latency: %d µs", r.Role.ID, actorID, latencyUs)
 	})
 
+	// Role Update - feed permission changes to escalation detection
+	s.AddHandler(func(sess *discordgo.Session, r *discordgo.GuildRoleUpdate) {
+		startTime := time.Now()
+
+		if r.GuildID == "" || r.Role == nil {
+			return
+		}
+
+		guildID, _ := strconv.ParseUint(r.GuildID, 10, 64)
+		roleIDNum, _ := strconv.ParseUint(r.Role.ID, 10, 64)
+		perms := uint64(r.Role.Permissions)
+
+		// Permission and position changes can both cost us our own reach
+		checkSelfRole(sess, ringBuffer, r.GuildID, 31, roleIDNum) // 31 = ROLE_UPDATE
+
+		// The correlator keeps the previous permissions, so every update is
+		// queued. Only a role that now holds a critical permission can have
+		// been escalated, so only those are worth an audit log lookup.
+		actorID, found := uint64(0), true
+		if perms&detectors.CriticalPermMask != 0 {
+			actorID, found = resolveActorForTarget(sess, r.GuildID, 31, roleIDNum) // 31 = ROLE_UPDATE
+		}
+
+		event := ingest.CreateEvent(
+			ingest.EventTypePermChange,
+			guildID,
+			actorID,
+			roleIDNum,
+			perms,
+		)
+		if !found {
+			event.Flags |= ingest.EventFlagUnresolved
+		}
+		ringBuffer.Enqueue(event)
+
+		if actorID != 0 {
+			latencyUs := time.Since(startTime).Microseconds()
+			logging.Info("[EVENT] Role update: %s by actor %d | Latency: %d µs", r.Role.ID, actorID, latencyUs)
+		}
+	})
+
 	// Role Delete
-	s.discord.AddHandler(func(sess *discordgo.Session, r *discordgo.GuildRoleDelete) {
+	s.AddHandler(func(sess *discordgo.Session, r *discordgo.GuildRoleDelete) {
 		startTime := time.Now()
 
 		if r.GuildID == "" {
@@ -535,7 +1156,19 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 		guildID, _ := strconv.ParseUint(r.GuildID, 10, 64)
 		roleIDNum, _ := strconv.ParseUint(r.RoleID, 10, 64)
 
-		actorID := fetchActorFromAuditLog(sess, r.GuildID, 32, roleIDNum) // 32 = ROLE_DELETE
+		// The state cache has already forgotten the role, remember the delete
+		state.GetRecentDeletes().Record(guildID, roleIDNum)
+
+		// Members lose the role once it is gone, drop it after the event is queued
+		defer state.GetMemberRoleCache().RemoveRole(guildID, roleIDNum)
+
+		checkSelfRole(sess, ringBuffer, r.GuildID, 32, roleIDNum) // 32 = ROLE_DELETE
+
+		if ingestedByGateway(sess, 32) {
+			return
+		}
+
+		actorID := fetchActorForTarget(sess, r.GuildID, 32, roleIDNum) // 32 = ROLE_DELETE
 
 		if actorID == 0 {
 			logging.Warn("[EVENT] Role delete but no actor ID: %s", r.RoleID)
@@ -558,6 +1191,254 @@ func (s *Session) SetupEventHandlers(ringBuffer *ingest.RingBuffer) {
 	logging.Info("Discord event handlers configured successfully (Direct Events + Audit Log Fetch)")
 }
 
+// parseRoleIDs converts Discord role ID strings to numeric IDs, skipping malformed ones
+func parseRoleIDs(ids []string) []uint64 {
+	roles := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if roleID, err := strconv.ParseUint(id, 10, 64); err == nil {
+			roles = append(roles, roleID)
+		}
+	}
+	return roles
+}
+
+// guildSettingsFrom extracts the settings protected against tampering
+func guildSettingsFrom(g *discordgo.Guild) state.GuildSettings {
+	systemChannelID, _ := strconv.ParseUint(g.SystemChannelID, 10, 64)
+	rulesChannelID, _ := strconv.ParseUint(g.RulesChannelID, 10, 64)
+	return state.GuildSettings{
+		Name:                  g.Name,
+		Icon:                  g.Icon,
+		VanityCode:            g.VanityURLCode,
+		VerificationLevel:     uint8(g.VerificationLevel),
+		MFALevel:              uint8(g.MfaLevel),
+		ExplicitContentFilter: uint8(g.ExplicitContentFilter),
+		SystemChannelID:       systemChannelID,
+		RulesChannelID:        rulesChannelID,
+	}
+}
+
+// seedRolePermissions queues unattributed permission changes so the
+// correlator learns each role's current permissions without scoring them
+func seedRolePermissions(ringBuffer *ingest.RingBuffer, guildID uint64, roles []*discordgo.Role) {
+	for _, role := range roles {
+		roleID, err := strconv.ParseUint(role.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		ringBuffer.Enqueue(ingest.CreateEvent(ingest.EventTypePermChange, guildID, 0, roleID, uint64(role.Permissions)))
+	}
+}
+
+type roleGrant struct {
+	roleID uint64
+	perms  uint64
+}
+
+// dangerousRoleGrants returns the roles in after but not in before that carry
+// a critical permission. Roles missing from the session state are skipped.
+func dangerousRoleGrants(sess *discordgo.Session, guildID string, before, after []uint64) []roleGrant {
+	var granted []roleGrant
+	for _, roleID := range after {
+		if containsID(before, roleID) {
+			continue
+		}
+		role, err := sess.State.Role(guildID, strconv.FormatUint(roleID, 10))
+		if err != nil {
+			continue
+		}
+		perms := uint64(role.Permissions)
+		if perms&detectors.CriticalPermMask != 0 {
+			granted = append(granted, roleGrant{roleID: roleID, perms: perms})
+		}
+	}
+	return granted
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// broadRoleShare is the share of cached members, in percent, a role needs
+// before a critical overwrite granted to it counts as tampering
+const broadRoleShare = 10
+
+// isBroadOverwriteTarget reports whether an overwrite applies to @everyone
+// or to a role held by a large part of the guild
+func isBroadOverwriteTarget(guildID uint64, image state.OverwriteImage) bool {
+	if image.Type != 0 {
+		return false
+	}
+	if image.TargetID == guildID {
+		return true
+	}
+	holders, members := state.GetMemberRoleCache().CountRole(guildID, image.TargetID)
+	return members > 0 && holders*100 >= members*broadRoleShare
+}
+
+// overwriteBeforeImage rebuilds an overwrite as it was before the audit
+// entry changed it. Update entries only list the keys that changed, so the
+// unchanged half comes from the cached channel.
+func overwriteBeforeImage(sess *discordgo.Session, entry *discordgo.AuditLogEntry, actionType int, channelID uint64) state.OverwriteImage {
+	image := state.OverwriteImage{
+		ChannelID: channelID,
+		Existed:   actionType != 13,
+	}
+	image.TargetID, _ = strconv.ParseUint(entry.Options.ID, 10, 64)
+	if entry.Options.Type != nil && *entry.Options.Type == discordgo.AuditLogOptionsTypeMember {
+		image.Type = 1
+	}
+	if !image.Existed {
+		return image
+	}
+
+	oldAllow, _, allowChanged := auditPermissionChange(entry.Changes, discordgo.AuditLogChangeKeyAllow)
+	oldDeny, _, denyChanged := auditPermissionChange(entry.Changes, discordgo.AuditLogChangeKeyDeny)
+	image.Allow, image.Deny = oldAllow, oldDeny
+	if allowChanged && denyChanged {
+		return image
+	}
+
+	channel, err := sess.State.Channel(strconv.FormatUint(channelID, 10))
+	if err != nil {
+		return image
+	}
+	for _, ow := range channel.PermissionOverwrites {
+		if ow.ID != entry.Options.ID {
+			continue
+		}
+		if !allowChanged {
+			image.Allow = uint64(ow.Allow)
+		}
+		if !denyChanged {
+			image.Deny = uint64(ow.Deny)
+		}
+		break
+	}
+	return image
+}
+
+// auditPermissionChange returns the old and new value of a permission key in
+// an audit entry, and whether the entry lists that key at all
+func auditPermissionChange(changes []*discordgo.AuditLogChange, key discordgo.AuditLogChangeKey) (oldPerms, newPerms uint64, found bool) {
+	for _, change := range changes {
+		if change.Key == nil || *change.Key != key {
+			continue
+		}
+		return parsePermissionValue(change.OldValue), parsePermissionValue(change.NewValue), true
+	}
+	return 0, 0, false
+}
+
+// parsePermissionValue decodes a permission bitset from an audit change value,
+// which Discord sends as a string
+func parsePermissionValue(value interface{}) uint64 {
+	switch v := value.(type) {
+	case string:
+		perms, _ := strconv.ParseUint(v, 10, 64)
+		return perms
+	case float64:
+		return uint64(v)
+	default:
+		return 0
+	}
+}
+
+// pingActor returns who a ping message is attributed to: its author, or for
+// webhook messages the webhook's creator. Webhooks we did not see created are
+// looked up once and tracked, so punishing the creator also deletes the
+// webhook. If the creator cannot be found it returns 0 and the ping is not
+// counted, since a webhook ID is not a member that can be punished.
+func pingActor(sess *discordgo.Session, guildID uint64, m *discordgo.Message) uint64 {
+	if m.WebhookID == "" {
+		actorID, _ := strconv.ParseUint(m.Author.ID, 10, 64)
+		return actorID
+	}
+
+	webhookID, err := strconv.ParseUint(m.WebhookID, 10, 64)
+	if err != nil {
+		return 0
+	}
+	registry := state.GetWebhookRegistry()
+	if creatorID, ok := registry.Creator(webhookID); ok {
+		return creatorID
+	}
+
+	webhook, err := sess.Webhook(m.WebhookID)
+	if err != nil || webhook.User == nil {
+		return 0
+	}
+	creatorID, _ := strconv.ParseUint(webhook.User.ID, 10, 64)
+	registry.Track(guildID, creatorID, webhookID)
+	return creatorID
+}
+
+// enqueueAssetChanges attributes every created, updated and deleted emoji or
+// sticker through its audit action (create, update, delete) and feeds it to
+// the correlator. Metadata carries the asset kind: 0 for emojis, 1 for stickers.
+func enqueueAssetChanges(sess *discordgo.Session, ringBuffer *ingest.RingBuffer, guildIDStr string, kind string, diff state.AssetDiff, actions [3]int) {
+	startTime := time.Now()
+	guildID, _ := strconv.ParseUint(guildIDStr, 10, 64)
+
+	metadata := uint64(state.AssetEmoji)
+	if kind == forensics.AssetKindSticker {
+		metadata = uint64(state.AssetSticker)
+	}
+
+	enqueue := func(eventType uint8, action int, assetID uint64) uint64 {
+		actorID := fetchActorForTarget(sess, guildIDStr, action, assetID)
+		if actorID == 0 {
+			return 0
+		}
+		event := ingest.CreateEvent(
+			eventType,
+			guildID,
+			actorID,
+			assetID,
+			metadata,
+		)
+		ringBuffer.Enqueue(event)
+		return actorID
+	}
+
+	for _, id := range diff.Created {
+		enqueue(ingest.EventTypeEmojiStickerCreate, actions[0], id)
+	}
+	for _, id := range diff.Updated {
+		enqueue(ingest.EventTypeEmojiStickerUpdate, actions[1], id)
+	}
+	for id, name := range diff.Deleted {
+		if actorID := enqueue(ingest.EventTypeEmojiStickerDelete, actions[2], id); actorID != 0 {
+			forensics.GetRecoveryTracker().TrackAssetDelete(guildID, id, actorID, kind, name)
+		}
+	}
+
+	logging.Info("[EVENT] %s changes: %d created, %d updated, %d deleted | Latency: %d µs",
+		kind, len(diff.Created), len(diff.Updated), len(diff.Deleted), time.Since(startTime).Microseconds())
+}
+
+// enqueueAutoModChange feeds an attributed AutoMod rule change to the
+// correlator. restore marks a change that disabled or deleted the rule, so it
+// is put back even below the limit.
+func enqueueAutoModChange(ringBuffer *ingest.RingBuffer, eventType uint8, guildID, actorID, ruleID uint64, restore bool) {
+	event := ingest.CreateEvent(
+		eventType,
+		guildID,
+		actorID,
+		ruleID,
+		0,
+	)
+	if restore {
+		event.Flags |= ingest.EventFlagRestoreRule
+	}
+	ringBuffer.Enqueue(event)
+}
+
 // mapAuditActionToEventType maps Discord audit log action types to internal event types
 func mapAuditActionToEventType(action int) uint8 {
 	switch action {
@@ -575,12 +1456,20 @@ func mapAuditActionToEventType(action int) uint8 {
 		return ingest.EventTypeRoleUpdate
 	case 20: // MEMBER_KICK
 		return ingest.EventTypeKick
+	case 21: // MEMBER_PRUNE
+		return ingest.EventTypeMemberPrune
 	case 22: // MEMBER_BAN_ADD
 		return ingest.EventTypeBan
 	case 23: // MEMBER_BAN_REMOVE
 		return ingest.EventTypeUnban
 	case 24: // MEMBER_UPDATE
 		return ingest.EventTypeMemberUpdate
+	case 13: // CHANNEL_OVERWRITE_CREATE
+		return ingest.EventTypeChannelOverwrite
+	case 14: // CHANNEL_OVERWRITE_UPDATE
+		return ingest.EventTypeChannelOverwrite
+	case 15: // CHANNEL_OVERWRITE_DELETE
+		return ingest.EventTypeChannelOverwrite
 	case 50: // WEBHOOK_CREATE
 		return ingest.EventTypeWebhook
 	case 51: // WEBHOOK_UPDATE
@@ -593,8 +1482,26 @@ func mapAuditActionToEventType(action int) uint8 {
 		return ingest.EventTypeEmojiStickerUpdate
 	case 62: // EMOJI_DELETE
 		return ingest.EventTypeEmojiStickerDelete
+	case 90: // STICKER_CREATE
+		return ingest.EventTypeEmojiStickerCreate
+	case 91: // STICKER_UPDATE
+		return ingest.EventTypeEmojiStickerUpdate
+	case 92: // STICKER_DELETE
+		return ingest.EventTypeEmojiStickerDelete
 	case 1: // GUILD_UPDATE
 		return ingest.EventTypeServerUpdate
+	case 100: // GUILD_SCHEDULED_EVENT_CREATE
+		return ingest.EventTypeGuildEventCreate
+	case 101: // GUILD_SCHEDULED_EVENT_UPDATE
+		return ingest.EventTypeGuildEventUpdate
+	case 102: // GUILD_SCHEDULED_EVENT_DELETE
+		return ingest.EventTypeGuildEventDelete
+	case 140: // AUTO_MODERATION_RULE_CREATE
+		return ingest.EventTypeAutomodRuleCreate
+	case 141: // AUTO_MODERATION_RULE_UPDATE
+		return ingest.EventTypeAutomodRuleUpdate
+	case 142: // AUTO_MODERATION_RULE_DELETE
+		return ingest.EventTypeAutomodRuleDelete
 	case 80: // INTEGRATION_CREATE
 		return ingest.EventTypeIntegration
 	case 81: // INTEGRATION_UPDATE
@@ -0,0 +1,135 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-antinuke-2.0/internal/config"
+	"go-antinuke-2.0/internal/database"
+	"go-antinuke-2.0/internal/logging"
+	"go-antinuke-2.0/internal/state"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// fakeEventGrace is how long an audit entry waits before its target is
+// checked, so the gateway event it describes has reached the state cache
+const fakeEventGrace = 5 * time.Second
+
+// fakeEventNames names the audit actions whose target the state cache can
+// vouch for. Members, unbans and webhooks are not cached reliably enough.
+var fakeEventNames = map[int]string{
+	10: "channel create", // CHANNEL_CREATE
+	11: "channel update", // CHANNEL_UPDATE
+	12: "channel delete", // CHANNEL_DELETE
+	30: "role create",    // ROLE_CREATE
+	31: "role update",    // ROLE_UPDATE
+	32: "role delete",    // ROLE_DELETE
+}
+
+// scheduleFakeEventCheck verifies an audit entry against the gateway state
+// once the event it describes has had time to arrive.
+func scheduleFakeEventCheck(sess *discordgo.Session, guildID string, actionType int, actorID, targetID string) {
+	name, ok := fakeEventNames[actionType]
+	if !ok || targetID == "" || isBotUser(sess, guildID, actorID) {
+		return
+	}
+
+	guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
+	if config.GetProfileStore().FakeEventPolicy(guildIDNum) == config.FakeEventOff {
+		return
+	}
+
+	time.AfterFunc(fakeEventGrace, func() {
+		if isFakeAuditEntry(sess, guildID, actionType, targetID) {
+			handleFakeEvent(sess, guildID, name, actorID, targetID)
+		}
+	})
+}
+
+// isFakeAuditEntry checks that the target is where the action left it:
+// created and updated targets must exist and deleted ones must be gone. A
+// target the gateway saw deleted explains either case. Without the guild in
+// the state cache nothing can be told, so the entry is trusted.
+func isFakeAuditEntry(sess *discordgo.Session, guildID string, actionType int, targetID string) bool {
+	if _, err := sess.State.Guild(guildID); err != nil {
+		return false
+	}
+
+	guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
+	targetIDNum, _ := strconv.ParseUint(targetID, 10, 64)
+	if state.GetRecentDeletes().WasDeleted(guildIDNum, targetIDNum) {
+		return false
+	}
+
+	exists := false
+	switch actionType {
+	case 10, 11, 12:
+		channel, err := sess.State.Channel(targetID)
+		exists = err == nil && channel.GuildID == guildID
+	case 30, 31, 32:
+		_, err := sess.State.Role(guildID, targetID)
+		exists = err == nil
+	}
+
+	if actionType == 12 || actionType == 32 {
+		return exists
+	}
+	return !exists
+}
+
+// handleFakeEvent applies the guild's fake event policy to the entry's author.
+func handleFakeEvent(sess *discordgo.Session, guildID, eventName, actorID, targetID string) {
+	guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
+	policy := config.GetProfileStore().FakeEventPolicy(guildIDNum)
+	if policy == config.FakeEventOff {
+		return
+	}
+
+	logging.Warn("[FAKE EVENT DETECTED] %s on target %s by %s in guild %s (policy: %s)",
+		eventName, targetID, actorID, guildID, policy)
+
+	actionTaken := "Logged only, no punishment"
+	if policy == config.FakeEventBan {
+		err := sess.GuildBanCreateWithReason(guildID, actorID,
+			fmt.Sprintf("Fake %s event detected - security violation", eventName), 0)
+		if err != nil {
+			logging.Error("Failed to ban fake event perpetrator %s: %v", actorID, err)
+			actionTaken = "Ban failed, check the bot's permissions"
+		} else {
+			logging.Info("[✓ FAKE EVENT BAN] Banned %s for creating fake %s event", actorID, eventName)
+			actionTaken = "Permanently banned"
+
+			if db := database.GetDB(); db != nil {
+				db.AddBannedUser(guildID, actorID,
+					fmt.Sprintf("Fake %s event creator", eventName),
+					"antinuke-bot", false, "")
+			}
+		}
+	}
+
+	// Send log to guild's log channel
+	db := database.GetDB()
+	if db == nil {
+		return
+	}
+	guildConfig, err := db.GetGuildConfig(guildID)
+	if err != nil || guildConfig == nil || guildConfig.LogChannelID == "" {
+		return
+	}
+
+	sess.ChannelMessageSendEmbed(guildConfig.LogChannelID, &discordgo.MessageEmbed{
+		Title: "🚨 FAKE EVENT DETECTED",
+		Description: fmt.Sprintf("User <@%s> has an audit log **%s** entry for `%s`, but the gateway never reported that change.\n\n**Action Taken:** %s",
+			actorID,
+			eventName,
+			targetID,
+			actionTaken),
+		Color:     0xFF0000,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Antinuke Security System",
+		},
+	})
+}
@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"strconv"
+	"sync"
+
+	"go-antinuke-2.0/internal/ingest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// gatewayIngest holds the IDs of the shards whose custom gateway reader is
+// live. That reader then queues the audit actions it slices itself, and the
+// shard's discordgo handlers for those actions only keep their side effects.
+//
+// The discordgo shard keeps GUILD_MODERATION even then, so both connections
+// receive GUILD_AUDIT_LOG_ENTRY_CREATE. The discordgo copy is not redundant:
+// it fills the join buffer every other action is attributed from, runs
+// scheduleFakeEventCheck on every entry including the sliced ones, and
+// carries the sliced actions while the reader is reconnecting. What the
+// reader saves is the join: its actions are queued when the entry arrives,
+// with bots dropped by the same isBotUser check, instead of waiting for it.
+var gatewayIngest sync.Map
+
+// SetGatewayIngest hands the actions the custom gateway reader slices over
+// to the shard's reader, or back to its discordgo handlers. Readers call it
+// as their session goes live and drops.
+func SetGatewayIngest(shardID int, enabled bool) {
+	if enabled {
+		gatewayIngest.Store(shardID, struct{}{})
+	} else {
+		gatewayIngest.Delete(shardID)
+	}
+}
+
+// ingestedByGateway reports whether a custom gateway reader queues events
+// for this audit action on sess's shard.
+func ingestedByGateway(sess *discordgo.Session, action int) bool {
+	if ingest.AuditActionEventType(action) == ingest.EventTypeUnknown {
+		return false
+	}
+	_, ok := gatewayIngest.Load(sess.ShardID)
+	return ok
+}
+
+// IsBotActor reports whether actorID is us or another bot. It is the
+// custom gateway reader's actor filter and only reads gateway state.
+func (s *Session) IsBotActor(guildID, actorID uint64) bool {
+	return isBotUser(s.ShardFor(guildID), strconv.FormatUint(guildID, 10), strconv.FormatUint(actorID, 10))
+}
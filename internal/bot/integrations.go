@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-antinuke-2.0/internal/config"
+	"go-antinuke-2.0/internal/database"
+	"go-antinuke-2.0/internal/ingest"
+	"go-antinuke-2.0/internal/logging"
+	"go-antinuke-2.0/internal/state"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// seenIntegrations remembers integrations already checked, since both
+// INTEGRATION_CREATE and GUILD_INTEGRATIONS_UPDATE report the same addition
+var seenIntegrations = struct {
+	mu  sync.Mutex
+	ids map[string]time.Time
+}{ids: make(map[string]time.Time)}
+
+// markIntegrationSeen returns false if the integration was already checked.
+func markIntegrationSeen(integrationID string) bool {
+	seenIntegrations.mu.Lock()
+	defer seenIntegrations.mu.Unlock()
+
+	if _, seen := seenIntegrations.ids[integrationID]; seen {
+		return false
+	}
+
+	now := time.Now()
+	for id, at := range seenIntegrations.ids {
+		if now.Sub(at) > targetMatchWindow {
+			delete(seenIntegrations.ids, id)
+		}
+	}
+	seenIntegrations.ids[integrationID] = now
+	return true
+}
+
+// checkRecentIntegrations looks for integrations added in the last few
+// seconds. GUILD_INTEGRATIONS_UPDATE only names the guild, so the audit log
+// is the only way to learn what was added and by whom.
+func checkRecentIntegrations(sess *discordgo.Session, guildID string) {
+	audit, err := sess.GuildAuditLog(guildID, "", "", 80, 5) // 80 = INTEGRATION_CREATE
+	if err != nil {
+		logging.Warn("Failed to fetch audit log for guild %s action 80: %v", guildID, err)
+		return
+	}
+
+	for _, entry := range audit.AuditLogEntries {
+		created, err := discordgo.SnowflakeTimestamp(entry.ID)
+		if err != nil || time.Since(created) > targetMatchWindow {
+			break
+		}
+
+		name := entry.TargetID
+		for _, change := range entry.Changes {
+			if change.Key != nil && *change.Key == discordgo.AuditLogChangeKeyName {
+				if s, ok := change.NewValue.(string); ok {
+					name = s
+				}
+			}
+		}
+		guardIntegration(sess, guildID, entry.TargetID, name, "", entry.UserID)
+	}
+}
+
+// guardIntegration removes an integration or application added by anyone but
+// the owner or a user whitelisted for integrations, the same policy applied
+// to bots joining in GuildMemberAdd. OAuth apps added with the bot scope show
+// up here as "discord" integrations, so apps without a bot user are covered
+// too.
+func guardIntegration(sess *discordgo.Session, guildID, integrationID, name, integrationType, adderID string) {
+	if integrationID == "" || !markIntegrationSeen(integrationID) {
+		return
+	}
+
+	guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
+	profile := config.GetProfileStore().Get(guildIDNum)
+	if profile == nil || !profile.IsEventEnabled(ingest.EventTypeIntegration) {
+		return
+	}
+
+	if adderID == "" {
+		logging.Warn("Could not determine who added integration %s (%s) in guild %s", name, integrationID, guildID)
+		return
+	}
+
+	adderIDNum, _ := strconv.ParseUint(adderID, 10, 64)
+	if adderIDNum == state.GetBotID() {
+		return
+	}
+
+	isOwner := profile.OwnerID == adderIDNum
+	isWhitelisted := config.GetProfileStore().IsWhitelisted(guildIDNum, adderIDNum, ingest.EventTypeIntegration)
+
+	if isOwner || isWhitelisted {
+		logging.Info("[✓ INTEGRATION ALLOWED] %s integration %s (%s) added by %s %s",
+			integrationType, name, integrationID,
+			map[bool]string{true: "owner", false: "whitelisted user"}[isOwner],
+			adderID)
+		return
+	}
+
+	// Unauthorized person added an integration - REMOVE IT
+	logging.Warn("[❌ UNAUTHORIZED INTEGRATION] %s integration %s (%s) added by unauthorized user %s - Removing",
+		integrationType, name, integrationID, adderID)
+
+	err := sess.GuildIntegrationDelete(guildID, integrationID,
+		discordgo.WithAuditLogReason("Integration added by non-whitelisted user - security policy"))
+	action := "Integration removed"
+	if err != nil {
+		logging.Error("Failed to remove unauthorized integration %s: %v", integrationID, err)
+		action = "Removal failed, remove it manually"
+	} else {
+		logging.Info("[✓ INTEGRATION REMOVED] %s", integrationID)
+	}
+
+	// Send log to guild's log channel
+	if db := database.GetDB(); db != nil {
+		guildConfig, err := db.GetGuildConfig(guildID)
+		if err == nil && guildConfig != nil && guildConfig.LogChannelID != "" {
+			sess.ChannelMessageSendEmbed(guildConfig.LogChannelID, &discordgo.MessageEmbed{
+				Title: "🚨 UNAUTHORIZED INTEGRATION DETECTED & REMOVED",
+				Description: fmt.Sprintf("Integration **%s** (`%s`) was added by <@%s> who is not authorized.\n\n**Action Taken:** %s\n\n**Note:** Only the server owner or whitelisted users can add integrations and apps.",
+					name,
+					integrationID,
+					adderID,
+					action),
+				Color:     0xFF0000,
+				Timestamp: time.Now().Format(time.RFC3339),
+			})
+		}
+	}
+}
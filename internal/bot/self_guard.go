@@ -0,0 +1,200 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-antinuke-2.0/internal/config"
+	"go-antinuke-2.0/internal/database"
+	"go-antinuke-2.0/internal/detectors"
+	"go-antinuke-2.0/internal/ingest"
+	"go-antinuke-2.0/internal/logging"
+	"go-antinuke-2.0/internal/state"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// banCriticalPermNames names each bit of detectors.BanCriticalPermMask for reports
+var banCriticalPermNames = []struct {
+	perm uint64
+	name string
+}{
+	{detectors.PermAdministrator, "Administrator"},
+	{detectors.PermBanMembers, "Ban Members"},
+	{detectors.PermKickMembers, "Kick Members"},
+	{detectors.PermModerateMembers, "Timeout Members"},
+	{detectors.PermManageRoles, "Manage Roles"},
+	{detectors.PermManageChannels, "Manage Channels"},
+	{detectors.PermManageWebhooks, "Manage Webhooks"},
+	{detectors.PermManageGuild, "Manage Server"},
+	{detectors.PermViewAuditLog, "View Audit Log"},
+}
+
+// selfRoleStatus reads the bot's permissions and top role position from the
+// session state, which discordgo updates before handlers run.
+func selfRoleStatus(sess *discordgo.Session, guildID string) (state.SelfRoleStatus, bool) {
+	var status state.SelfRoleStatus
+
+	guild, err := sess.State.Guild(guildID)
+	if err != nil || sess.State.User == nil {
+		return status, false
+	}
+	member, err := sess.State.Member(guildID, sess.State.User.ID)
+	if err != nil {
+		return status, false
+	}
+
+	held := make(map[string]bool, len(member.Roles))
+	for _, id := range member.Roles {
+		held[id] = true
+	}
+
+	var top *discordgo.Role
+	for _, role := range guild.Roles {
+		if role.ID == guildID || held[role.ID] {
+			status.Permissions |= uint64(role.Permissions)
+		}
+		if held[role.ID] && (top == nil || role.Position > top.Position) {
+			top = role
+		}
+	}
+
+	if top != nil {
+		status.TopRoleID, _ = strconv.ParseUint(top.ID, 10, 64)
+		status.Position = top.Position
+	}
+	for _, role := range guild.Roles {
+		if role.Position > status.Position {
+			roleID, _ := strconv.ParseUint(role.ID, 10, 64)
+			status.Above = append(status.Above, roleID)
+		}
+	}
+
+	if guild.OwnerID == sess.State.User.ID {
+		status.Permissions = detectors.BanCriticalPermMask
+	}
+	return status, true
+}
+
+// checkSelfRole diffs the bot's status after a role or member change. Any
+// ban-critical permission lost, or any role newly above the bot's top role,
+// is attributed and punished at once, and reported to the owner.
+// actionType and targetID name the audit entry of the change that was seen.
+func checkSelfRole(sess *discordgo.Session, ringBuffer *ingest.RingBuffer, guildID string, actionType int, targetID uint64) {
+	status, ok := selfRoleStatus(sess, guildID)
+	if !ok {
+		return
+	}
+
+	guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
+	before, known := state.GetSelfRoleCache().Update(guildIDNum, status)
+	if !known {
+		return
+	}
+
+	lost := before.Permissions &^ status.Permissions & detectors.BanCriticalPermMask
+	var overtaken []uint64
+	for _, roleID := range status.Above {
+		if !containsID(before.Above, roleID) {
+			overtaken = append(overtaken, roleID)
+		}
+	}
+	if lost == 0 && len(overtaken) == 0 {
+		return
+	}
+
+	// A role moved above ours has its own audit entry, ours may have none.
+	// The lookups run side by side so each one waits for its entry at once
+	actors := make([]uint64, 1+len(overtaken))
+	var wg sync.WaitGroup
+	for i := range actors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i == 0 {
+				actors[i] = fetchActorForTarget(sess, guildID, actionType, targetID)
+			} else {
+				actors[i] = fetchActorForTarget(sess, guildID, 31, overtaken[i-1]) // 31 = ROLE_UPDATE
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	actorID := uint64(0)
+	for _, id := range actors {
+		if id != 0 {
+			actorID = id
+			break
+		}
+	}
+
+	if actorID != 0 {
+		// Metadata carries the ban-critical permissions lost
+		event := ingest.CreateEvent(
+			ingest.EventTypeRoleUpdate,
+			guildIDNum,
+			actorID,
+			targetID,
+			lost,
+		)
+		event.Flags |= ingest.EventFlagSelfRole
+		ringBuffer.Enqueue(event)
+	}
+
+	logging.Warn("[SELF ROLE] Guild %s: lost permissions %#x, %d role(s) now above ours, actor %d",
+		guildID, lost, len(overtaken), actorID)
+	reportSelfRoleDamage(sess, guildID, actorID, before, status, lost, overtaken)
+}
+
+// reportSelfRoleDamage tells the log channel and the owner exactly what the
+// bot lost, since until it is fixed bans and restores will fail.
+func reportSelfRoleDamage(sess *discordgo.Session, guildID string, actorID uint64, before, after state.SelfRoleStatus, lost uint64, overtaken []uint64) {
+	var lines []string
+	for _, p := range banCriticalPermNames {
+		if lost&p.perm != 0 {
+			lines = append(lines, "❌ Lost permission: **"+p.name+"**")
+		}
+	}
+	if after.Position < before.Position {
+		lines = append(lines, fmt.Sprintf("❌ Top role <@&%d> moved down from position %d to %d", after.TopRoleID, before.Position, after.Position))
+	}
+	for _, roleID := range overtaken {
+		lines = append(lines, fmt.Sprintf("❌ <@&%d> is now above the bot and out of its reach", roleID))
+	}
+
+	actor := "an unknown user"
+	if actorID != 0 {
+		actor = fmt.Sprintf("<@%d>", actorID)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: "🚨 ANTI-NUKE ROLE TAMPERED WITH",
+		Description: fmt.Sprintf("The anti-nuke's own role was changed by %s.\n\n%s\n\n**Action Required:** Restore the role's permissions and move it back to the top, or bans and restores will fail.",
+			actor,
+			strings.Join(lines, "\n")),
+		Color:     0xFF0000,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	if db := database.GetDB(); db != nil {
+		guildConfig, err := db.GetGuildConfig(guildID)
+		if err == nil && guildConfig != nil && guildConfig.LogChannelID != "" {
+			sess.ChannelMessageSendEmbed(guildConfig.LogChannelID, embed)
+		}
+	}
+
+	guildIDNum, _ := strconv.ParseUint(guildID, 10, 64)
+	profile := config.GetProfileStore().Get(guildIDNum)
+	if profile == nil || profile.OwnerID == 0 {
+		return
+	}
+	dm, err := sess.UserChannelCreate(strconv.FormatUint(profile.OwnerID, 10))
+	if err != nil {
+		logging.Warn("Failed to open DM with owner of guild %s: %v", guildID, err)
+		return
+	}
+	sess.ChannelMessageSendEmbed(dm.ID, embed)
+}
@@ -3,6 +3,8 @@ package bot
 import (
 	"fmt"
 	"strconv"
+	"sync"
+	"time"
 
 	"go-antinuke-2.0/internal/logging"
 	"go-antinuke-2.0/internal/state"
@@ -10,29 +12,82 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
+// Session is the bot's gateway presence: one discordgo session per shard.
+// Shard 0 doubles as the REST client.
 type Session struct {
-	discord *discordgo.Session
-	token   string
-	BotID   uint64
+	discord        *discordgo.Session
+	shards         []*discordgo.Session
+	maxConcurrency int
+	token          string
+	BotID          uint64
 }
 
+// ShardStatus is a snapshot of one shard's connection.
+type ShardStatus struct {
+	ID        int
+	Guilds    int
+	Latency   time.Duration
+	Connected bool
+}
+
+// IdentifyInterval is how long an identify bucket waits between identifies
+const IdentifyInterval = 5 * time.Second
+
 var globalSession *Session
 
-// Initialize creates and initializes the Discord session
-func Initialize(token string) error {
+// Initialize creates one Discord session per shard. The shard count is
+// Discord's recommendation from /gateway/bot, raised to minShards when more
+// gateway connections are wanted for throughput.
+func Initialize(token string, minShards int) error {
 	dg, err := discordgo.New("Bot " + token)
 	if err != nil {
 		return fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
-	// Set required intents - enable ALL intents for comprehensive event detection
-	dg.Identify.Intents = discordgo.IntentsAll
+	shardCount, maxConcurrency := minShards, 1
+	gateway, err := dg.GatewayBot()
+	if err != nil {
+		logging.Warn("Failed to fetch gateway info, using %d shard(s): %v", minShards, err)
+	} else {
+		if gateway.Shards > shardCount {
+			shardCount = gateway.Shards
+		}
+		if gateway.SessionStartLimit.MaxConcurrency > 1 {
+			maxConcurrency = gateway.SessionStartLimit.MaxConcurrency
+		}
+		if gateway.SessionStartLimit.Remaining < shardCount {
+			logging.Warn("Only %d session starts left for %d shards, resets in %v",
+				gateway.SessionStartLimit.Remaining, shardCount,
+				time.Duration(gateway.SessionStartLimit.ResetAfter)*time.Millisecond)
+		}
+	}
+	if shardCount < 1 {
+		shardCount = 1
+	}
+
+	shards := make([]*discordgo.Session, shardCount)
+	for id := range shards {
+		if id > 0 {
+			if dg, err = discordgo.New("Bot " + token); err != nil {
+				return fmt.Errorf("failed to create Discord session for shard %d: %w", id, err)
+			}
+		}
+
+		// Set required intents - enable ALL intents for comprehensive event detection
+		dg.Identify.Intents = discordgo.IntentsAll
+		dg.ShardID = id
+		dg.ShardCount = shardCount
+		shards[id] = dg
+	}
 
 	globalSession = &Session{
-		discord: dg,
-		token:   token,
+		discord:        shards[0],
+		shards:         shards,
+		maxConcurrency: maxConcurrency,
+		token:          token,
 	}
 
+	logging.Info("Using %d shard(s), identifying %d at a time", shardCount, maxConcurrency)
 	return nil
 }
 
@@ -46,10 +101,51 @@ func (s *Session) GetDiscord() *discordgo.Session {
 	return s.discord
 }
 
-// Connect opens the Discord websocket connection
+// ShardCount returns the number of gateway shards.
+func (s *Session) ShardCount() int {
+	return len(s.shards)
+}
+
+// MaxConcurrency returns how many shards may identify at the same time.
+func (s *Session) MaxConcurrency() int {
+	return s.maxConcurrency
+}
+
+// ShardFor returns the session of the shard that receives guildID's events.
+func (s *Session) ShardFor(guildID uint64) *discordgo.Session {
+	return s.shards[(guildID>>22)%uint64(len(s.shards))]
+}
+
+// Connect opens every shard's websocket connection. Shards identify in
+// buckets of max_concurrency, one bucket per identify interval.
 func (s *Session) Connect() error {
-	if err := s.discord.Open(); err != nil {
-		return fmt.Errorf("failed to open Discord connection: %w", err)
+	for start := 0; start < len(s.shards); start += s.maxConcurrency {
+		if start > 0 {
+			time.Sleep(IdentifyInterval)
+		}
+
+		end := start + s.maxConcurrency
+		if end > len(s.shards) {
+			end = len(s.shards)
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, end-start)
+		for i, shard := range s.shards[start:end] {
+			wg.Add(1)
+			go func(i int, shard *discordgo.Session) {
+				defer wg.Done()
+				errs[i] = shard.Open()
+			}(i, shard)
+		}
+		wg.Wait()
+
+		for i, err := range errs {
+			if err != nil {
+				return fmt.Errorf("failed to open Discord connection for shard %d: %w", start+i, err)
+			}
+		}
+		logging.Info("Shards %d-%d of %d connected", start, end-1, len(s.shards))
 	}
 
 	// Store bot ID
@@ -64,12 +160,48 @@ func (s *Session) Connect() error {
 	return nil
 }
 
-// Close closes the Discord connection
+// Close closes every shard's connection
 func (s *Session) Close() error {
-	if s.discord != nil {
-		return s.discord.Close()
+	var firstErr error
+	for _, shard := range s.shards {
+		if err := shard.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-	return nil
+	return firstErr
+}
+
+// GuildCount returns the number of guilds across all shards.
+func (s *Session) GuildCount() int {
+	count := 0
+	for _, shard := range s.shards {
+		shard.State.RLock()
+		count += len(shard.State.Guilds)
+		shard.State.RUnlock()
+	}
+	return count
+}
+
+// ShardStatus returns a snapshot of every shard's connection.
+func (s *Session) ShardStatus() []ShardStatus {
+	statuses := make([]ShardStatus, len(s.shards))
+	for id, shard := range s.shards {
+		shard.State.RLock()
+		guilds := len(shard.State.Guilds)
+		shard.State.RUnlock()
+
+		shard.RLock()
+		connected := shard.DataReady
+		shard.RUnlock()
+
+		statuses[id] = ShardStatus{
+			ID:        id,
+			Guilds:    guilds,
+			Latency:   shard.HeartbeatLatency(),
+			Connected: connected,
+		}
+	}
+	return statuses
 }
 
 // RegisterCommands registers all slash commands with Discord
@@ -87,9 +219,11 @@ func (s *Session) RegisterCommands(commands []*discordgo.ApplicationCommand) err
 	return nil
 }
 
-// AddHandler adds an event handler to the Discord session
+// AddHandler adds an event handler to every shard
 func (s *Session) AddHandler(handler interface{}) {
-	s.discord.AddHandler(handler)
+	for _, shard := range s.shards {
+		shard.AddHandler(handler)
+	}
 }
 
 // SyncGuildsFromDatabase syncs all guild configurations from database to in-memory store
@@ -105,9 +239,11 @@ func (s *Session) SyncGuildsFromDatabase(db interface {
 	}
 
 	// Ensure all current guilds in the bot have configs
-	for _, guild := range s.discord.State.Guilds {
-		if err := db.EnsureGuildConfigExists(guild.ID); err != nil {
-			logging.Warn("Failed to ensure config for guild %s: %v", guild.ID, err)
+	for _, shard := range s.shards {
+		for _, guild := range shard.State.Guilds {
+			if err := db.EnsureGuildConfigExists(guild.ID); err != nil {
+				logging.Warn("Failed to ensure config for guild %s: %v", guild.ID, err)
+			}
 		}
 	}
 
@@ -103,6 +103,33 @@ func GetAllCommands() []*discordgo.ApplicationCommand {
 						},
 					},
 				},
+				{
+					Name:        "fakeevents",
+					Description: "Choose how audit log entries for non-existent targets are handled",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "policy",
+							Description: "What to do with whoever created the entry",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{
+									Name:  "Off",
+									Value: "off",
+								},
+								{
+									Name:  "Log Only",
+									Value: "log",
+								},
+								{
+									Name:  "Ban",
+									Value: "ban",
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 		{
@@ -135,6 +162,12 @@ func GetAllCommands() []*discordgo.ApplicationCommand {
 						},
 					},
 				},
+				{
+					Name:        "duration",
+					Description: "Timeout length in minutes (default from config)",
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Required:    false,
+				},
 			},
 		},
 		{
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	cfg "go-antinuke-2.0/internal/config"
+	"go-antinuke-2.0/internal/database"
+	"go-antinuke-2.0/pkg/util"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// fakeEventPolicyDescriptions explains each /set fakeevents policy
+var fakeEventPolicyDescriptions = map[cfg.FakeEventPolicy]string{
+	cfg.FakeEventOff: "Fake audit log events are ignored.",
+	cfg.FakeEventLog: "Fake audit log events are reported to the log channel. Nobody is punished.",
+	cfg.FakeEventBan: "Whoever creates a fake audit log event is banned and reported to the log channel.",
+}
+
+// handleSetFakeEvents handles /set fakeevents
+func handleSetFakeEvents(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	// Check permissions
+	allowed, err := checkPermissions(s, i)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		respondPermissionError(s, i, "You need Administrator permission and a role higher than the bot.")
+		return nil
+	}
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 || len(data.Options[0].Options) == 0 {
+		return fmt.Errorf("missing policy")
+	}
+	policy := cfg.ParseFakeEventPolicy(data.Options[0].Options[0].StringValue())
+
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	config, err := db.GetGuildConfig(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	config.FakeEventPolicy = policy.String()
+	if err := db.UpsertGuildConfig(config); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	// Sync with in-memory store so the next audit entry uses the new policy
+	if id, err := util.StringToUint64(i.GuildID); err == nil {
+		cfg.GetProfileStore().SetFakeEventPolicy(id, policy)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Fake Event Policy Updated",
+		Description: fakeEventPolicyDescriptions[policy],
+		Color:       0x2B2D31,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Policy",
+				Value:  policy.String(),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Anti-Nuke Security Systems • Enterprise Grade Protection",
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -129,6 +129,10 @@ func (h *Handler) handleComponent(s *discordgo.Session, i *discordgo.Interaction
 		err = handleWhitelistAddAll(s, i)
 	case strings.HasPrefix(data.CustomID, "whitelist_remove_all_"):
 		err = handleWhitelistRemoveAll(s, i)
+	case strings.HasPrefix(data.CustomID, "wl_sel_"):
+		err = handleWhitelistAddScoped(s, i)
+	case strings.HasPrefix(data.CustomID, "wl_rm_sel_"):
+		err = handleWhitelistRemoveScoped(s, i)
 
 	default:
 		// Fallback for existing components
@@ -180,18 +184,14 @@ func serializeEnabledEvents(enabled map[int]bool) string {
 
 // IsEventEnabled checks if an event type is enabled for a guild
 func IsEventEnabled(guildID string, eventType int) bool {
-	db := database.GetDB()
-	if db == nil {
-		return false
-	}
-
-	config, err := db.GetGuildConfig(guildID)
-	if err != nil {
+	// Use the compiled mask kept in sync by the enable/disable handlers
+	id, err := util.StringToUint64(guildID)
+	if err != nil || eventType < 0 || eventType >= config.MaxEventTypes {
 		return false
 	}
 
-	enabled := parseEnabledEvents(config.EnabledEvents)
-	return enabled[eventType]
+	profile := config.GetProfileStore().Get(id)
+	return profile != nil && profile.IsEventEnabled(uint8(eventType))
 }
 
 // IsPanicMode checks if panic mode is enabled for a guild
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"go-antinuke-2.0/internal/config"
 	"go-antinuke-2.0/internal/database"
 
 	"github.com/bwmarrin/discordgo"
@@ -69,17 +70,20 @@ func handleSetLimit(s *discordgo.Session, i *discordgo.InteractionCreate) error
 	// Get existing limit to preserve punishment setting
 	existingLimit, err := db.GetEventLimit(i.GuildID, eventID)
 	punishment := "ban" // Default
+	timeoutSeconds := 0
 	if err == nil && existingLimit != nil {
 		punishment = existingLimit.Punishment
+		timeoutSeconds = existingLimit.TimeoutSeconds
 	}
 
 	// Save new limit
 	newLimit := &database.EventLimit{
-		GuildID:    i.GuildID,
-		EventType:  eventID,
-		MaxActions: int(limit),
-		TimeWindow: int(timeWindow),
-		Punishment: punishment,
+		GuildID:        i.GuildID,
+		EventType:      eventID,
+		MaxActions:     int(limit),
+		TimeWindow:     int(timeWindow),
+		Punishment:     punishment,
+		TimeoutSeconds: timeoutSeconds,
 	}
 
 	if err := db.UpsertEventLimit(newLimit); err != nil {
@@ -153,6 +157,7 @@ func handleSetPunishment(s *discordgo.Session, i *discordgo.InteractionCreate) e
 
 	var action string
 	var punishment string
+	var durationMinutes int64
 
 	for _, opt := range options {
 		switch opt.Name {
@@ -160,6 +165,8 @@ func handleSetPunishment(s *discordgo.Session, i *discordgo.InteractionCreate) e
 			action = opt.StringValue()
 		case "punishment":
 			punishment = opt.StringValue()
+		case "duration":
+			durationMinutes = opt.IntValue()
 		}
 	}
 
@@ -174,6 +181,11 @@ func handleSetPunishment(s *discordgo.Session, i *discordgo.InteractionCreate) e
 		return fmt.Errorf("invalid punishment type. Must be ban, kick, or timeout")
 	}
 
+	// Discord caps timeouts at 28 days
+	if durationMinutes < 0 || durationMinutes*60 > config.MaxTimeoutSeconds {
+		return fmt.Errorf("invalid duration. Must be between 1 and %d minutes", config.MaxTimeoutSeconds/60)
+	}
+
 	// Parse action to event ID
 	eventID, err := strconv.Atoi(action)
 	if err != nil {
@@ -200,19 +212,30 @@ func handleSetPunishment(s *discordgo.Session, i *discordgo.InteractionCreate) e
 	existingLimit, err := db.GetEventLimit(i.GuildID, eventID)
 	maxActions := 3
 	timeWindow := 10
+	timeoutSeconds := 0
 
 	if err == nil && existingLimit != nil {
 		maxActions = existingLimit.MaxActions
 		timeWindow = existingLimit.TimeWindow
+		timeoutSeconds = existingLimit.TimeoutSeconds
+	}
+
+	// A duration only applies to timeouts; without one the previous or default length stays
+	if durationMinutes > 0 {
+		timeoutSeconds = int(durationMinutes * 60)
+	}
+	if punishment != "timeout" {
+		timeoutSeconds = 0
 	}
 
 	// Save new punishment
 	newLimit := &database.EventLimit{
-		GuildID:    i.GuildID,
-		EventType:  eventID,
-		MaxActions: maxActions,
-		TimeWindow: timeWindow,
-		Punishment: punishment,
+		GuildID:        i.GuildID,
+		EventType:      eventID,
+		MaxActions:     maxActions,
+		TimeWindow:     timeWindow,
+		Punishment:     punishment,
+		TimeoutSeconds: timeoutSeconds,
 	}
 
 	if err := db.UpsertEventLimit(newLimit); err != nil {
@@ -246,6 +269,18 @@ func handleSetPunishment(s *discordgo.Session, i *discordgo.InteractionCreate) e
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
 
+	if punishment == "timeout" {
+		duration := "Config default"
+		if timeoutSeconds > 0 {
+			duration = fmt.Sprintf("`%d` minutes", timeoutSeconds/60)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Timeout Length",
+			Value:  duration,
+			Inline: true,
+		})
+	}
+
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
@@ -6,6 +6,8 @@ import (
 	"runtime"
 	"time"
 
+	"go-antinuke-2.0/internal/bot"
+
 	"github.com/bwmarrin/discordgo"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/disk"
@@ -195,7 +197,7 @@ func gatherSystemStats(s *discordgo.Session) (*SystemStats, error) {
 
 	// Bot Statistics
 	stats.BotUptime = time.Since(botStartTime)
-	stats.Guilds = len(s.State.Guilds)
+	stats.Guilds = bot.GetSession().GuildCount()
 	stats.Latency = s.HeartbeatLatency()
 
 	return stats, nil
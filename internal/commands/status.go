@@ -5,7 +5,9 @@ import (
 	"strings"
 	"time"
 
+	"go-antinuke-2.0/internal/bot"
 	"go-antinuke-2.0/internal/database"
+	"go-antinuke-2.0/pkg/util"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -101,6 +103,11 @@ func handleStatus(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 				Value:  logChannelText,
 				Inline: false,
 			},
+			{
+				Name:   "Gateway Shards",
+				Value:  shardStatusText(guildID),
+				Inline: false,
+			},
 			{
 				Name:   "Active Protection Modules",
 				Value:  enabledText,
@@ -136,3 +143,37 @@ func handleStatus(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 		},
 	})
 }
+
+// shardStatusText summarises the gateway shards and names the one serving guildID
+func shardStatusText(guildID string) string {
+	session := bot.GetSession()
+	if session == nil {
+		return "Unavailable"
+	}
+
+	statuses := session.ShardStatus()
+	var connected int
+	var latency time.Duration
+	var down []string
+	for _, status := range statuses {
+		if status.Connected {
+			connected++
+			latency += status.Latency
+		} else {
+			down = append(down, fmt.Sprintf("%d", status.ID))
+		}
+	}
+
+	lines := make([]string, 0, 3)
+	if id, err := util.StringToUint64(guildID); err == nil {
+		lines = append(lines, fmt.Sprintf("This server: shard %d of %d", session.ShardFor(id).ShardID, len(statuses)))
+	}
+	if connected > 0 {
+		latency /= time.Duration(connected)
+	}
+	lines = append(lines, fmt.Sprintf("%d/%d connected • avg latency %dms", connected, len(statuses), latency.Milliseconds()))
+	if len(down) > 0 {
+		lines = append(lines, "Disconnected: "+strings.Join(down, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
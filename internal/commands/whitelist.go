@@ -67,6 +67,16 @@ func handleWhitelistAdd(s *discordgo.Session, i *discordgo.InteractionCreate) er
 		shortType = "r"
 	}
 
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	eventTypes, err := db.GetEventTypes()
+	if err != nil {
+		return fmt.Errorf("failed to fetch event types: %w", err)
+	}
+
 	components := []discordgo.MessageComponent{
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
@@ -78,6 +88,7 @@ func handleWhitelistAdd(s *discordgo.Session, i *discordgo.InteractionCreate) er
 			},
 		},
 	}
+	components = append(components, buildWhitelistScopeMenus(eventTypes, shortType, targetID)...)
 
 	embed := &discordgo.MessageEmbed{
 		Title:       "Whitelist Configuration",
@@ -86,7 +97,7 @@ func handleWhitelistAdd(s *discordgo.Session, i *discordgo.InteractionCreate) er
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "Instructions",
-				Value:  "Click **Whitelist for All Events** below to grant full immunity.",
+				Value:  "Click **Whitelist for All Events** below to grant full immunity, or pick specific events from the menus to limit the exception to them.",
 				Inline: false,
 			},
 		},
@@ -182,6 +193,22 @@ func handleWhitelistRemove(s *discordgo.Session, i *discordgo.InteractionCreate)
 		},
 	}
 
+	eventTypeMap, err := loadEventTypeMap(db)
+	if err != nil {
+		return err
+	}
+
+	shortType := "u"
+	if targetType == "role" {
+		shortType = "r"
+	}
+	components = append(components, buildWhitelistRemoveMenu(whitelists, eventTypeMap, shortType, targetID))
+
+	scope := make([]string, 0, len(whitelists))
+	for _, w := range whitelists {
+		scope = append(scope, whitelistScopeLabel(w.EventType, eventTypeMap))
+	}
+
 	embed := &discordgo.MessageEmbed{
 		Title:       "Remove Whitelist",
 		Description: fmt.Sprintf("Revoke security exceptions for **%s**.", targetName),
@@ -189,7 +216,12 @@ func handleWhitelistRemove(s *discordgo.Session, i *discordgo.InteractionCreate)
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "Instructions",
-				Value:  "Click **Remove All Whitelists** below to revoke all immunities.",
+				Value:  "Click **Remove All Whitelists** below to revoke all immunities, or pick events from the menu to revoke only those.",
+				Inline: false,
+			},
+			{
+				Name:   "Current Scope",
+				Value:  strings.Join(scope, ", "),
 				Inline: false,
 			},
 		},
@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-antinuke-2.0/internal/database"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord caps select menus at 25 options, so event types are split across menus
+const maxSelectOptions = 25
+
+// buildWhitelistScopeMenus builds the select menus used to whitelist a target
+// for specific events only.
+// wl_sel_0_u_123456 -> chunk 0, user 123456
+func buildWhitelistScopeMenus(eventTypes []*database.EventType, shortType, targetID string) []discordgo.MessageComponent {
+	var rows []discordgo.MessageComponent
+
+	for start, chunk := 0, 0; start < len(eventTypes); start, chunk = start+maxSelectOptions, chunk+1 {
+		end := start + maxSelectOptions
+		if end > len(eventTypes) {
+			end = len(eventTypes)
+		}
+
+		options := make([]discordgo.SelectMenuOption, 0, end-start)
+		for _, et := range eventTypes[start:end] {
+			options = append(options, discordgo.SelectMenuOption{
+				Label: et.Description,
+				Value: strconv.Itoa(et.ID),
+			})
+		}
+
+		minValues := 1
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    fmt.Sprintf("wl_sel_%d_%s_%s", chunk, shortType, targetID),
+					Placeholder: fmt.Sprintf("Whitelist for specific events (%d-%d)", start+1, end),
+					MinValues:   &minValues,
+					MaxValues:   len(options),
+					Options:     options,
+				},
+			},
+		})
+	}
+
+	return rows
+}
+
+// buildWhitelistRemoveMenu builds the select menu listing a target's current
+// whitelist scope so individual events can be revoked.
+func buildWhitelistRemoveMenu(whitelists []*database.Whitelist, eventTypeMap map[int]string, shortType, targetID string) discordgo.MessageComponent {
+	options := make([]discordgo.SelectMenuOption, 0, len(whitelists))
+	for _, w := range whitelists {
+		if len(options) == maxSelectOptions {
+			break
+		}
+		options = append(options, discordgo.SelectMenuOption{
+			Label: whitelistScopeLabel(w.EventType, eventTypeMap),
+			Value: strconv.Itoa(w.EventType),
+		})
+	}
+
+	minValues := 1
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    fmt.Sprintf("wl_rm_sel_%s_%s", shortType, targetID),
+				Placeholder: "Revoke specific events",
+				MinValues:   &minValues,
+				MaxValues:   len(options),
+				Options:     options,
+			},
+		},
+	}
+}
+
+func whitelistScopeLabel(eventType int, eventTypeMap map[int]string) string {
+	if eventType == 0 {
+		return "All Events"
+	}
+	if desc, ok := eventTypeMap[eventType]; ok {
+		return desc
+	}
+	return fmt.Sprintf("Event ID %d", eventType)
+}
+
+func loadEventTypeMap(db *database.Database) (map[int]string, error) {
+	eventTypes, err := db.GetEventTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch event types: %w", err)
+	}
+
+	eventTypeMap := make(map[int]string, len(eventTypes))
+	for _, et := range eventTypes {
+		eventTypeMap[et.ID] = et.Description
+	}
+	return eventTypeMap, nil
+}
+
+// handleWhitelistAddScoped handles the event select menus from /antinuke whitelist add
+func handleWhitelistAddScoped(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	data := i.MessageComponentData()
+	parts := strings.Split(data.CustomID, "_")
+	if len(parts) < 5 {
+		return fmt.Errorf("invalid custom ID")
+	}
+
+	// wl_sel_0_u_123456
+	shortType := parts[3]
+	targetID := parts[4]
+
+	targetType := "user"
+	if shortType == "r" {
+		targetType = "role"
+	}
+
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	eventTypeMap, err := loadEventTypeMap(db)
+	if err != nil {
+		return err
+	}
+
+	var scope []string
+	for _, value := range data.Values {
+		eventType, err := strconv.Atoi(value)
+		if err != nil || eventTypeMap[eventType] == "" {
+			continue
+		}
+		if err := db.AddWhitelist(i.GuildID, targetID, targetType, eventType); err != nil {
+			return err
+		}
+		scope = append(scope, eventTypeMap[eventType])
+	}
+
+	if len(scope) == 0 {
+		return fmt.Errorf("no valid events selected")
+	}
+
+	// Sync whitelist to in-memory store for real-time checking
+	if err := db.SyncWhitelistToMemory(i.GuildID); err != nil {
+		return fmt.Errorf("failed to sync whitelist: %w", err)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Whitelist Updated",
+		Description: "Target has been whitelisted for the selected security events only.",
+		Color:       0x2B2D31,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Scope",
+				Value:  strings.Join(scope, "\n"),
+				Inline: false,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Anti-Nuke Security Systems • Enterprise Grade Protection",
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// handleWhitelistRemoveScoped handles the revoke select menu from /antinuke whitelist remove
+func handleWhitelistRemoveScoped(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	data := i.MessageComponentData()
+	parts := strings.Split(data.CustomID, "_")
+	if len(parts) < 5 {
+		return fmt.Errorf("invalid custom ID")
+	}
+
+	// wl_rm_sel_u_123456
+	targetID := parts[4]
+
+	db := database.GetDB()
+	if db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	eventTypeMap, err := loadEventTypeMap(db)
+	if err != nil {
+		return err
+	}
+
+	var revoked []string
+	for _, value := range data.Values {
+		eventType, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		if err := db.RemoveWhitelist(i.GuildID, targetID, eventType); err != nil {
+			return err
+		}
+		revoked = append(revoked, whitelistScopeLabel(eventType, eventTypeMap))
+	}
+
+	if len(revoked) == 0 {
+		return fmt.Errorf("no valid events selected")
+	}
+
+	// Sync whitelist to in-memory store after removal
+	if err := db.SyncWhitelistToMemory(i.GuildID); err != nil {
+		return fmt.Errorf("failed to sync whitelist: %w", err)
+	}
+
+	remaining, err := db.GetWhitelistByTarget(i.GuildID, targetID)
+	if err != nil {
+		return err
+	}
+
+	remainingScope := "None"
+	if len(remaining) > 0 {
+		labels := make([]string, 0, len(remaining))
+		for _, w := range remaining {
+			labels = append(labels, whitelistScopeLabel(w.EventType, eventTypeMap))
+		}
+		remainingScope = strings.Join(labels, ", ")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Whitelist Updated",
+		Description: "Selected security exceptions have been revoked.",
+		Color:       0x2B2D31,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Revoked",
+				Value:  strings.Join(revoked, "\n"),
+				Inline: false,
+			},
+			{
+				Name:   "Remaining Scope",
+				Value:  remainingScope,
+				Inline: false,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Anti-Nuke Security Systems • Enterprise Grade Protection",
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
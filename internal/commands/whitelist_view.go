@@ -55,34 +55,26 @@ func handleWhitelistView(s *discordgo.Session, i *discordgo.InteractionCreate) e
 		})
 	}
 
-	// Get event types for descriptions
-	eventTypes, err := db.GetEventTypes()
+	eventTypeMap, err := loadEventTypeMap(db)
 	if err != nil {
-		return fmt.Errorf("failed to fetch event types: %w", err)
-	}
-
-	eventTypeMap := make(map[int]string)
-	for _, et := range eventTypes {
-		eventTypeMap[et.ID] = et.Description
+		return err
 	}
 
-	// Group by target
+	// Group by target; an all-events entry makes any scoped rows redundant
 	targetMap := make(map[string][]string)
 	targetTypeMap := make(map[string]string)
+	allEvents := make(map[string]bool)
 
 	for _, w := range whitelists {
-		eventDesc := "All Events"
-		if w.EventType != 0 {
-			if desc, ok := eventTypeMap[w.EventType]; ok {
-				eventDesc = desc
-			} else {
-				eventDesc = fmt.Sprintf("Event ID %d", w.EventType)
-			}
+		if w.EventType == 0 {
+			allEvents[w.TargetID] = true
 		}
-
-		targetMap[w.TargetID] = append(targetMap[w.TargetID], eventDesc)
+		targetMap[w.TargetID] = append(targetMap[w.TargetID], whitelistScopeLabel(w.EventType, eventTypeMap))
 		targetTypeMap[w.TargetID] = w.TargetType
 	}
+	for targetID := range allEvents {
+		targetMap[targetID] = []string{whitelistScopeLabel(0, eventTypeMap)}
+	}
 
 	// Build the whitelist display
 	var userList []string
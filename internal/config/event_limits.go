@@ -0,0 +1,102 @@
+package config
+
+import (
+	"sync/atomic"
+)
+
+// MaxEventTypes bounds the per-guild limit table. Event type IDs match the
+// event_types table and the ingest.EventType* constants.
+const MaxEventTypes = 32
+
+type Punishment uint8
+
+const (
+	PunishBan Punishment = iota
+	PunishKick
+	PunishTimeout
+)
+
+// MaxTimeoutSeconds is the longest timeout Discord accepts, 28 days.
+const MaxTimeoutSeconds = 28 * 24 * 60 * 60
+
+// EventLimit is the admin-configured "MaxActions within WindowMs" rule for one
+// event type. Configured is false for slots that fall back to the size matrix.
+// TimeoutSeconds is the length of a "timeout" punishment, 0 for the default.
+type EventLimit struct {
+	MaxActions     uint32
+	WindowMs       uint32
+	TimeoutSeconds uint32
+	Punishment     Punishment
+	Configured     bool
+	_              [2]byte
+}
+
+// EventLimitSet is the preallocated per-guild threshold table, indexed by event type.
+type EventLimitSet [MaxEventTypes]EventLimit
+
+// eventLimitTable holds the active set for a guild. The set is replaced as a
+// whole so the correlator never observes a partially written table.
+type eventLimitTable struct {
+	active atomic.Pointer[EventLimitSet]
+}
+
+var emptyLimitSet = &EventLimitSet{}
+
+func (t *eventLimitTable) load() *EventLimitSet {
+	if set := t.active.Load(); set != nil {
+		return set
+	}
+	return emptyLimitSet
+}
+
+// EventLimit returns the configured limit for an event type.
+func (p *GuildProfile) EventLimit(eventType uint8) EventLimit {
+	if eventType >= MaxEventTypes {
+		return EventLimit{}
+	}
+	return p.limits.load()[eventType]
+}
+
+// SetEventLimits atomically swaps the guild's limit table.
+func (ps *ProfileStore) SetEventLimits(guildID uint64, set *EventLimitSet) {
+	profile := ps.GetOrCreate(guildID)
+	profile.limits.active.Store(set)
+}
+
+// ResolveLimit returns the effective limit for an event type: the admin
+// configured one if present, otherwise the threshold from the size matrix.
+func ResolveLimit(profile *GuildProfile, matrix ThresholdMatrix, eventType uint8, fallback uint32) EventLimit {
+	limit := profile.EventLimit(eventType)
+	if limit.Configured {
+		return limit
+	}
+	return EventLimit{
+		MaxActions: fallback,
+		WindowMs:   matrix.WindowMs,
+		Punishment: PunishBan,
+	}
+}
+
+func ParsePunishment(s string) Punishment {
+	switch s {
+	case "kick":
+		return PunishKick
+	case "timeout":
+		return PunishTimeout
+	default:
+		return PunishBan
+	}
+}
+
+func (p Punishment) String() string {
+	switch p {
+	case PunishBan:
+		return "ban"
+	case PunishKick:
+		return "kick"
+	case PunishTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// eventMaskCompiled marks a mask loaded from guild_config. Until a guild has
+// been synced every event stays enabled, matching the profile's Enabled default.
+const eventMaskCompiled = uint64(1) << 63
+
+// eventMask is the compiled form of guild_config.enabled_events: bit N is set
+// when event type N is enabled.
+type eventMask struct {
+	bits atomic.Uint64
+}
+
+// ParseEventMask compiles a comma-separated enabled_events list into a bitmask.
+// Unknown or out-of-range IDs are ignored.
+func ParseEventMask(enabled string) uint64 {
+	mask := uint64(0)
+	for _, part := range strings.Split(enabled, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || id <= 0 || id >= MaxEventTypes {
+			continue
+		}
+		mask |= 1 << uint(id)
+	}
+	return mask
+}
+
+// IsEventEnabled reports whether detection for an event type is turned on.
+func (p *GuildProfile) IsEventEnabled(eventType uint8) bool {
+	bits := p.events.bits.Load()
+	if bits&eventMaskCompiled == 0 {
+		return true
+	}
+	if eventType >= MaxEventTypes {
+		return false
+	}
+	return bits&(1<<eventType) != 0
+}
+
+// SetEnabledEvents swaps in a freshly compiled mask for the guild.
+func (ps *ProfileStore) SetEnabledEvents(guildID uint64, mask uint64) {
+	profile := ps.GetOrCreate(guildID)
+	profile.events.bits.Store(mask | eventMaskCompiled)
+}
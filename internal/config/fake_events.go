@@ -0,0 +1,58 @@
+package config
+
+// FakeEventPolicy decides what happens to someone whose audit log entry names
+// a channel or role that, by the gateway's account, never took that action.
+type FakeEventPolicy uint8
+
+const (
+	// FakeEventLog reports the entry to the log channel only. It is the zero
+	// value so guilds that never chose a policy are not punished on a hunch.
+	FakeEventLog FakeEventPolicy = iota
+	FakeEventBan
+	FakeEventOff
+)
+
+func ParseFakeEventPolicy(s string) FakeEventPolicy {
+	switch s {
+	case "ban":
+		return FakeEventBan
+	case "off":
+		return FakeEventOff
+	default:
+		return FakeEventLog
+	}
+}
+
+func (p FakeEventPolicy) String() string {
+	switch p {
+	case FakeEventLog:
+		return "log"
+	case FakeEventBan:
+		return "ban"
+	case FakeEventOff:
+		return "off"
+	default:
+		return "unknown"
+	}
+}
+
+// SetFakeEventPolicy sets how the guild responds to fake audit log events.
+func (ps *ProfileStore) SetFakeEventPolicy(guildID uint64, policy FakeEventPolicy) {
+	profile := ps.GetOrCreate(guildID)
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	profile.FakeEvents = policy
+}
+
+// FakeEventPolicy returns the guild's fake event policy.
+func (ps *ProfileStore) FakeEventPolicy(guildID uint64) FakeEventPolicy {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	profile, exists := ps.profiles[guildID]
+	if !exists {
+		return FakeEventLog
+	}
+	return profile.FakeEvents
+}
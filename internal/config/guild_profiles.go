@@ -12,9 +12,12 @@ type GuildProfile struct {
 	SafetyMode       SafetyMode
 	PanicMode        bool
 	OwnerID          uint64
-	Whitelist        []uint64
-	TrustedRoles     []uint64
+	Whitelist        []WhitelistEntry
+	TrustedRoles     []WhitelistEntry
 	CustomThresholds *ThresholdMatrix
+	FakeEvents       FakeEventPolicy
+	limits           eventLimitTable
+	events           eventMask
 }
 
 type ProfileStore struct {
@@ -67,14 +70,15 @@ func (ps *ProfileStore) GetOrCreate(guildID uint64) *GuildProfile {
 		Enabled:      true,
 		SafetyMode:   SafetyNormal,
 		PanicMode:    false,
-		Whitelist:    make([]uint64, 0),
-		TrustedRoles: make([]uint64, 0),
+		Whitelist:    make([]WhitelistEntry, 0),
+		TrustedRoles: make([]WhitelistEntry, 0),
 	}
 	ps.profiles[guildID] = profile
 	return profile
 }
 
-func (ps *ProfileStore) IsWhitelisted(guildID, userID uint64) bool {
+// IsWhitelisted reports whether the user is exempt from the event type.
+func (ps *ProfileStore) IsWhitelisted(guildID, userID uint64, eventType uint8) bool {
 	ps.mu.RLock()
 	defer ps.mu.RUnlock()
 
@@ -83,15 +87,55 @@ func (ps *ProfileStore) IsWhitelisted(guildID, userID uint64) bool {
 		return false
 	}
 
-	for _, wid := range profile.Whitelist {
-		if wid == userID {
-			return true
+	for _, entry := range profile.Whitelist {
+		if entry.ID == userID {
+			return entry.Covers(eventType)
 		}
 	}
 	return false
 }
 
-func (ps *ProfileStore) AddWhitelist(guildID, userID uint64) {
+// HasTrustedRole reports whether any of the roles is whitelisted for the event type.
+func (ps *ProfileStore) HasTrustedRole(guildID uint64, roles []uint64, eventType uint8) bool {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	profile, exists := ps.profiles[guildID]
+	if !exists || len(profile.TrustedRoles) == 0 {
+		return false
+	}
+
+	for _, role := range roles {
+		for _, trusted := range profile.TrustedRoles {
+			if role == trusted.ID && trusted.Covers(eventType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasTrustedRoles reports whether the guild whitelists any role at all.
+func (ps *ProfileStore) HasTrustedRoles(guildID uint64) bool {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	profile, exists := ps.profiles[guildID]
+	return exists && len(profile.TrustedRoles) > 0
+}
+
+// SetWhitelist replaces the guild's user and role whitelists.
+func (ps *ProfileStore) SetWhitelist(guildID uint64, users, roles []WhitelistEntry) {
+	profile := ps.GetOrCreate(guildID)
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	profile.Whitelist = users
+	profile.TrustedRoles = roles
+}
+
+// AddWhitelist extends the user's scope with the given event types.
+func (ps *ProfileStore) AddWhitelist(guildID, userID uint64, scope uint64) {
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 
@@ -102,18 +146,19 @@ func (ps *ProfileStore) AddWhitelist(guildID, userID uint64) {
 			Enabled:    true,
 			SafetyMode: SafetyNormal,
 			PanicMode:  false,
-			Whitelist:  []uint64{userID},
+			Whitelist:  []WhitelistEntry{{ID: userID, Events: scope}},
 		}
 		ps.profiles[guildID] = profile
 		return
 	}
 
-	for _, wid := range profile.Whitelist {
-		if wid == userID {
+	for i := range profile.Whitelist {
+		if profile.Whitelist[i].ID == userID {
+			profile.Whitelist[i].Events |= scope
 			return
 		}
 	}
-	profile.Whitelist = append(profile.Whitelist, userID)
+	profile.Whitelist = append(profile.Whitelist, WhitelistEntry{ID: userID, Events: scope})
 }
 
 func (ps *ProfileStore) RemoveWhitelist(guildID, userID uint64) {
@@ -125,8 +170,8 @@ func (ps *ProfileStore) RemoveWhitelist(guildID, userID uint64) {
 		return
 	}
 
-	for i, wid := range profile.Whitelist {
-		if wid == userID {
+	for i, entry := range profile.Whitelist {
+		if entry.ID == userID {
 			profile.Whitelist = append(profile.Whitelist[:i], profile.Whitelist[i+1:]...)
 			return
 		}
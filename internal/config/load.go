@@ -24,6 +24,11 @@ type DetectionConfig struct {
 	DefaultMode   string `json:"default_mode"`
 	ThresholdFile string `json:"threshold_file"`
 	GuildProfiles string `json:"guild_profiles"`
+	// RevertRoleGrants removes roles carrying critical permissions again after they are granted
+	RevertRoleGrants bool `json:"revert_role_grants"`
+	// TimeoutSeconds is how long the "timeout" punishment lasts when the
+	// event's limit does not set its own duration
+	TimeoutSeconds int `json:"timeout_seconds"`
 }
 
 type RuntimeConfig struct {
@@ -42,6 +47,25 @@ type NetworkConfig struct {
 	HTTPPoolSize  int    `json:"http_pool_size"`
 	WorkerCount   int    `json:"worker_count"`
 	APIBaseURL    string `json:"api_base_url"`
+	// GatewayMode selects how audit-relevant events are ingested: "discordgo"
+	// unmarshals them through the bot session, "custom" slices them from a
+	// dedicated gateway connection
+	GatewayMode     string `json:"gateway_mode"`
+	GatewayCompress bool   `json:"gateway_compress"`
+	// MinShards raises the shard count above Discord's recommendation when
+	// more gateway connections are wanted; 0 keeps the recommendation
+	MinShards int `json:"min_shards"`
+}
+
+const (
+	GatewayModeDiscordgo = "discordgo"
+	GatewayModeCustom    = "custom"
+)
+
+// CustomGateway reports whether events are ingested through the custom
+// gateway reader.
+func (n NetworkConfig) CustomGateway() bool {
+	return n.GatewayMode == GatewayModeCustom
 }
 
 type ForensicsConfig struct {
@@ -100,8 +124,10 @@ func DefaultConfig() *Config {
 	return &Config{
 		Bot: BotConfig{},
 		Detection: DetectionConfig{
-			Enabled:     true,
-			DefaultMode: "normal",
+			Enabled:          true,
+			DefaultMode:      "normal",
+			RevertRoleGrants: true,
+			TimeoutSeconds:   24 * 60 * 60,
 		},
 		Runtime: RuntimeConfig{
 			DisableGC:     true,
@@ -118,11 +144,13 @@ func DefaultConfig() *Config {
 			HTTPPoolSize:  8,
 			WorkerCount:   8,
 			APIBaseURL:    "https://discord.com/api/v10",
+			GatewayMode:   GatewayModeDiscordgo,
 		},
 		Forensics: ForensicsConfig{
 			Enabled:        true,
 			RetentionDays:  90,
 			AuditInterval:  5000,
+			SnapshotPath:   "./snapshots",
 			LogCompression: true,
 		},
 		HA: HAConfig{
@@ -11,66 +11,113 @@ const (
 )
 
 type ThresholdMatrix struct {
-	BanThreshold      uint32
-	KickThreshold     uint32
-	ChannelThreshold  uint32
-	RoleThreshold     uint32
-	WebhookThreshold  uint32
-	PermThreshold     uint32
-	VelocityThreshold uint32
-	WindowMs          uint32
+	BanThreshold         uint32
+	KickThreshold        uint32
+	ChannelThreshold     uint32
+	RoleThreshold        uint32
+	WebhookThreshold     uint32
+	PermThreshold        uint32
+	PruneThreshold       uint32 // members removed by prunes, not prune calls
+	OverwriteThreshold   uint32 // critical overwrites granted to @everyone or broad roles
+	GuildUpdateThreshold uint32 // critical guild setting changes; a vanity change counts fully
+	EmojiThreshold       uint32 // emoji and sticker creates, updates or deletes
+	AutoModThreshold     uint32 // AutoMod rule creates, updates or deletes
+	GuildEventThreshold  uint32 // scheduled event creates, updates or deletes
+	PingThreshold        uint32 // @everyone, @here or role ping messages
+	VelocityThreshold    uint32
+	WindowMs             uint32 // sliding window every threshold above is counted over
 }
 
+// DefaultThresholdMatrix keeps the baseline's per-size windows of 100 to
+// 250ms, scaled 20x for the sliding counters: a window that short never held
+// more than one action. Windows grow more slowly than the thresholds, so
+// bigger guilds tolerate faster moderation (3 bans/s when huge, 1.5 when
+// tiny), while an API nuke still runs past them within its first seconds.
 var DefaultThresholdMatrix = map[GuildSizeCategory]ThresholdMatrix{
 	SizeTiny: {
-		BanThreshold:      3,
-		KickThreshold:     5,
-		ChannelThreshold:  1,
-		RoleThreshold:     1,
-		WebhookThreshold:  5,
-		PermThreshold:     3,
-		VelocityThreshold: 10,
-		WindowMs:          100,
+		BanThreshold:         3,
+		KickThreshold:        5,
+		ChannelThreshold:     1,
+		RoleThreshold:        1,
+		WebhookThreshold:     5,
+		PermThreshold:        3,
+		PruneThreshold:       10,
+		OverwriteThreshold:   2,
+		GuildUpdateThreshold: 2,
+		EmojiThreshold:       3,
+		AutoModThreshold:     2,
+		GuildEventThreshold:  3,
+		PingThreshold:        2,
+		VelocityThreshold:    10,
+		WindowMs:             2000,
 	},
 	SizeSmall: {
-		BanThreshold:      5,
-		KickThreshold:     8,
-		ChannelThreshold:  1,
-		RoleThreshold:     1,
-		WebhookThreshold:  8,
-		PermThreshold:     5,
-		VelocityThreshold: 15,
-		WindowMs:          100,
+		BanThreshold:         5,
+		KickThreshold:        8,
+		ChannelThreshold:     1,
+		RoleThreshold:        1,
+		WebhookThreshold:     8,
+		PermThreshold:        5,
+		PruneThreshold:       25,
+		OverwriteThreshold:   3,
+		GuildUpdateThreshold: 2,
+		EmojiThreshold:       5,
+		AutoModThreshold:     2,
+		GuildEventThreshold:  3,
+		PingThreshold:        3,
+		VelocityThreshold:    15,
+		WindowMs:             2000,
 	},
 	SizeMedium: {
-		BanThreshold:      7,
-		KickThreshold:     12,
-		ChannelThreshold:  5,
-		RoleThreshold:     5,
-		WebhookThreshold:  10,
-		PermThreshold:     7,
-		VelocityThreshold: 20,
-		WindowMs:          150,
+		BanThreshold:         7,
+		KickThreshold:        12,
+		ChannelThreshold:     5,
+		RoleThreshold:        5,
+		WebhookThreshold:     10,
+		PermThreshold:        7,
+		PruneThreshold:       50,
+		OverwriteThreshold:   5,
+		GuildUpdateThreshold: 3,
+		EmojiThreshold:       5,
+		AutoModThreshold:     3,
+		GuildEventThreshold:  4,
+		PingThreshold:        3,
+		VelocityThreshold:    20,
+		WindowMs:             3000,
 	},
 	SizeLarge: {
-		BanThreshold:      10,
-		KickThreshold:     15,
-		ChannelThreshold:  7,
-		RoleThreshold:     7,
-		WebhookThreshold:  15,
-		PermThreshold:     10,
-		VelocityThreshold: 30,
-		WindowMs:          200,
+		BanThreshold:         10,
+		KickThreshold:        15,
+		ChannelThreshold:     7,
+		RoleThreshold:        7,
+		WebhookThreshold:     15,
+		PermThreshold:        10,
+		PruneThreshold:       100,
+		OverwriteThreshold:   7,
+		GuildUpdateThreshold: 3,
+		EmojiThreshold:       7,
+		AutoModThreshold:     3,
+		GuildEventThreshold:  5,
+		PingThreshold:        4,
+		VelocityThreshold:    30,
+		WindowMs:             4000,
 	},
 	SizeHuge: {
-		BanThreshold:      15,
-		KickThreshold:     20,
-		ChannelThreshold:  10,
-		RoleThreshold:     10,
-		WebhookThreshold:  20,
-		PermThreshold:     15,
-		VelocityThreshold: 40,
-		WindowMs:          250,
+		BanThreshold:         15,
+		KickThreshold:        20,
+		ChannelThreshold:     10,
+		RoleThreshold:        10,
+		WebhookThreshold:     20,
+		PermThreshold:        15,
+		PruneThreshold:       250,
+		OverwriteThreshold:   10,
+		GuildUpdateThreshold: 3,
+		EmojiThreshold:       10,
+		AutoModThreshold:     4,
+		GuildEventThreshold:  5,
+		PingThreshold:        5,
+		VelocityThreshold:    40,
+		WindowMs:             5000,
 	},
 }
 
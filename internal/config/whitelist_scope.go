@@ -0,0 +1,28 @@
+package config
+
+// AllEventsScope exempts a whitelist target from every event type. It is the
+// in-memory form of a whitelist row with event_type = 0.
+const AllEventsScope = ^uint64(0)
+
+// WhitelistEntry is one whitelisted user or role and the event types it is
+// exempt from: bit N set means event type N is covered.
+type WhitelistEntry struct {
+	ID     uint64
+	Events uint64
+}
+
+// EventScope converts a whitelist event_type column value into a scope mask.
+func EventScope(eventType int) uint64 {
+	if eventType <= 0 || eventType >= MaxEventTypes {
+		return AllEventsScope
+	}
+	return 1 << uint(eventType)
+}
+
+// Covers reports whether the entry exempts its target from the event type.
+func (e WhitelistEntry) Covers(eventType uint8) bool {
+	if eventType >= MaxEventTypes {
+		return e.Events == AllEventsScope
+	}
+	return e.Events&(1<<eventType) != 0
+}
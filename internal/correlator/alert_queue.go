@@ -5,16 +5,20 @@ import (
 )
 
 type Alert struct {
-	GuildID   uint64
-	ActorID   uint64
-	TargetID  uint64
-	EventType uint8
-	Severity  uint8
-	PanicMode uint8
-	_         uint8
-	Flags     uint32
-	Timestamp int64
-	_         [12]byte
+	GuildID    uint64
+	ActorID    uint64
+	TargetID   uint64
+	EventType  uint8
+	Severity   uint8
+	PanicMode  uint8
+	Punishment uint8
+	Flags      uint32
+	Timestamp  int64
+	Metadata   uint64
+	Revert     uint8
+	_          [3]byte
+	// TimeoutSeconds is the length of a "timeout" punishment, 0 for the default
+	TimeoutSeconds uint32
 }
 
 type AlertQueue struct {
@@ -13,32 +13,46 @@ import (
 )
 
 type Correlator struct {
-	ringBuffer         *ingest.RingBuffer
-	alertQueue         *AlertQueue
-	banDetector        *detectors.BanDetector
-	channelDetector    *detectors.ChannelDeleteDetector
-	roleDetector       *detectors.RoleDeleteDetector
-	permDetector       *detectors.PermissionDetector
-	velocityDetector   *detectors.VelocityDetector
-	multiActorDetector *detectors.MultiActorDetector
-	flagDetector       *detectors.FlagDetector
-	running            bool
-	cpuCore            int
+	ringBuffer          *ingest.RingBuffer
+	alertQueue          *AlertQueue
+	banDetector         *detectors.BanDetector
+	kickDetector        *detectors.KickDetector
+	pruneDetector       *detectors.PruneDetector
+	webhookDetector     *detectors.WebhookDetector
+	channelDetector     *detectors.ChannelDeleteDetector
+	roleDetector        *detectors.RoleDeleteDetector
+	permDetector        *detectors.PermissionDetector
+	roleGrantDetector   *detectors.RoleGrantDetector
+	overwriteDetector   *detectors.OverwriteDetector
+	guildUpdateDetector *detectors.GuildUpdateDetector
+	windowDetector      *detectors.WindowDetector
+	velocityDetector    *detectors.VelocityDetector
+	multiActorDetector  *detectors.MultiActorDetector
+	flagDetector        *detectors.FlagDetector
+	running             bool
+	cpuCore             int
 }
 
 func NewCorrelator(ringBuffer *ingest.RingBuffer, alertQueue *AlertQueue, cpuCore int) *Correlator {
 	return &Correlator{
-		ringBuffer:         ringBuffer,
-		alertQueue:         alertQueue,
-		banDetector:        detectors.NewBanDetector(),
-		channelDetector:    detectors.NewChannelDeleteDetector(),
-		roleDetector:       detectors.NewRoleDeleteDetector(),
-		permDetector:       detectors.NewPermissionDetector(),
-		velocityDetector:   detectors.NewVelocityDetector(),
-		multiActorDetector: detectors.NewMultiActorDetector(),
-		flagDetector:       detectors.NewFlagDetector(),
-		running:            false,
-		cpuCore:            cpuCore,
+		ringBuffer:          ringBuffer,
+		alertQueue:          alertQueue,
+		banDetector:         detectors.NewBanDetector(),
+		kickDetector:        detectors.NewKickDetector(),
+		pruneDetector:       detectors.NewPruneDetector(),
+		webhookDetector:     detectors.NewWebhookDetector(),
+		channelDetector:     detectors.NewChannelDeleteDetector(),
+		roleDetector:        detectors.NewRoleDeleteDetector(),
+		permDetector:        detectors.NewPermissionDetector(),
+		roleGrantDetector:   detectors.NewRoleGrantDetector(),
+		overwriteDetector:   detectors.NewOverwriteDetector(),
+		guildUpdateDetector: detectors.NewGuildUpdateDetector(),
+		windowDetector:      detectors.NewWindowDetector(),
+		velocityDetector:    detectors.NewVelocityDetector(),
+		multiActorDetector:  detectors.NewMultiActorDetector(),
+		flagDetector:        detectors.NewFlagDetector(),
+		running:             false,
+		cpuCore:             cpuCore,
 	}
 }
 
@@ -93,12 +107,22 @@ func (c *Correlator) processEvent(event *ingest.Event, guildMap *state.GuildIDMa
 		guildIndex = guildMap.Register(event.GuildID)
 	}
 
+	// Every role update refreshes the permission cache, including unattributed
+	// ones (actor 0) such as startup seeds and our own restores, so escalations
+	// are always diffed against what the role really had before. An update
+	// whose actor could not be found is skipped instead, so a possible
+	// escalation is not taken as the baseline and the next attributed update
+	// still diffs against the old permissions
+	var escalated bool
+	var previousPerms uint64
+	if event.EventType == ingest.EventTypePermChange && event.Flags&ingest.EventFlagUnresolved == 0 {
+		previousPerms, _ = c.permDetector.Previous(event.TargetID)
+		escalated, _ = c.permDetector.Detect(event.TargetID, event.Metadata)
+	}
+
 	actorIndex := uint32(0)
 	if event.ActorID != 0 {
-		actorIndex = actorMap.GetIndex(event.ActorID)
-		if actorIndex == 0 {
-			actorIndex = actorMap.Register(event.ActorID)
-		}
+		actorIndex = actorMap.Register(event.GuildID, event.ActorID)
 	} else {
 		return
 	}
@@ -109,6 +133,12 @@ func (c *Correlator) processEvent(event *ingest.Event, guildMap *state.GuildIDMa
 		return
 	}
 
+	// Respect /antinuke enable and disable before any detector sees the event
+	scopedType := toggleEventType(event.EventType)
+	if !profile.IsEventEnabled(scopedType) {
+		return
+	}
+
 	// CRITICAL: Never punish the bot itself or server owner
 	if event.ActorID == state.GetBotID() {
 		// fmt.Printf("[CORRELATOR] Skipping event - actor is the bot itself (%d)\n", event.ActorID)
@@ -120,16 +150,34 @@ func (c *Correlator) processEvent(event *ingest.Event, guildMap *state.GuildIDMa
 		return
 	}
 
-	if actorIndex != 0 && profileStore.IsWhitelisted(event.GuildID, event.ActorID) {
+	if actorIndex != 0 && (profileStore.IsWhitelisted(event.GuildID, event.ActorID, scopedType) ||
+		hasWhitelistedRole(profileStore, event.GuildID, event.ActorID, scopedType)) {
 		// fmt.Printf("[CORRELATOR] Skipping event - actor %d is whitelisted\n", event.ActorID)
 		return
 	}
 
 	as := state.GetActorState()
 
+	// Dangerous role grants, escalations, guild setting changes and disabled
+	// or deleted AutoMod rules are reported even below the limit, and after
+	// the actor already triggered, so the decision engine can revert each
+	// one. The alert carries what to revert: the granted role, the
+	// permissions the role had before, or the changed settings fields; rules
+	// are named by the event target.
+	revertMetadata := event.Metadata
+	needsRevert := event.EventType == ingest.EventTypeMemberUpdate || event.EventType == ingest.EventTypeServerUpdate ||
+		event.Flags&ingest.EventFlagRestoreRule != 0
+	if escalated {
+		revertMetadata = previousPerms
+		needsRevert = true
+	}
+
 	// In panic mode, check if actor is already triggered OR banned to skip processing
 	// This prevents race conditions where multiple events slip through before ban executes
 	if profile.PanicMode {
+		if needsRevert && (as.IsBanned(actorIndex) || as.IsTriggered(actorIndex)) {
+			c.queueAlert(event, 0, 0, config.EventLimit{}, true, revertMetadata)
+		}
 		if as.IsBanned(actorIndex) {
 			fmt.Printf("[CORRELATOR] PANIC MODE - Actor %d already banned, skipping event\n", event.ActorID)
 			return
@@ -154,6 +202,26 @@ func (c *Correlator) processEvent(event *ingest.Event, guildMap *state.GuildIDMa
 		switch event.EventType {
 		case ingest.EventTypeBan:
 			flag = detectors.FlagBanTriggered
+		case ingest.EventTypeKick:
+			flag = detectors.FlagKickTriggered
+		case ingest.EventTypeMemberPrune:
+			flag = detectors.FlagPruneTriggered
+		case ingest.EventTypeWebhook:
+			flag = detectors.FlagWebhookTriggered
+		case ingest.EventTypeMemberUpdate, ingest.EventTypePermChange:
+			flag = detectors.FlagPermissionTriggered
+		case ingest.EventTypeChannelOverwrite:
+			flag = detectors.FlagOverwriteTriggered
+		case ingest.EventTypeServerUpdate:
+			flag = detectors.FlagGuildUpdateTriggered
+		case ingest.EventTypeEmojiStickerCreate, ingest.EventTypeEmojiStickerUpdate, ingest.EventTypeEmojiStickerDelete:
+			flag = detectors.FlagAssetTriggered
+		case ingest.EventTypeAutomodRuleCreate, ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+			flag = detectors.FlagAutoModTriggered
+		case ingest.EventTypeGuildEventCreate, ingest.EventTypeGuildEventUpdate, ingest.EventTypeGuildEventDelete:
+			flag = detectors.FlagGuildEventTriggered
+		case ingest.EventTypeEveryoneHerePing, ingest.EventTypeRolePing:
+			flag = detectors.FlagPingTriggered
 		case ingest.EventTypeChannelCreate, ingest.EventTypeChannelDelete:
 			flag = detectors.FlagChannelTriggered
 		case ingest.EventTypeRoleCreate, ingest.EventTypeRoleDelete:
@@ -161,59 +229,160 @@ func (c *Correlator) processEvent(event *ingest.Event, guildMap *state.GuildIDMa
 		default:
 			flag = detectors.FlagBanTriggered // Default to ban for any malicious event
 		}
+		if event.Flags&ingest.EventFlagSelfRole != 0 {
+			flag = detectors.FlagSelfRoleTriggered
+		}
 
 		// Queue alert immediately with zero-cost timestamp
 		alert := c.alertQueue.Get()
 		alert.GuildID = event.GuildID
 		alert.ActorID = event.ActorID
 		alert.EventType = event.EventType
+		alert.TargetID = event.TargetID
 		alert.Flags = flag
 		alert.Timestamp = 0 // Skip timing in panic mode for max speed
 		alert.Severity = detectors.GetSeverityFromFlags(flag)
 		alert.PanicMode = 1
+		if needsRevert {
+			alert.Metadata = revertMetadata
+			alert.Revert = 1
+		}
 		c.alertQueue.Enqueue(alert)
 		return // Skip normal detection path
 	}
 
-	// NORMAL MODE: Full detection with thresholds
-	thresholds := config.GetGuildThresholds(event.GuildID, profile.MemberCount)
+	// NORMAL MODE: Full detection with the guild's configured limit for this event type
+	limit := resolveEventLimit(profile, event.EventType)
 
 	if alreadyTriggered {
+		if needsRevert {
+			c.queueAlert(event, 0, 0, limit, true, revertMetadata)
+		}
 		return
 	}
 
 	detectionStart := util.NowMono()
 	flags := uint32(0)
 
+	// Damage to our own role is punished at once, whatever the limit: every
+	// later ban depends on that role's permissions and position
+	if event.Flags&ingest.EventFlagSelfRole != 0 {
+		flags = c.flagDetector.SetFlag(flags, detectors.FlagSelfRoleTriggered)
+	}
+
 	switch event.EventType {
 	case ingest.EventTypeBan:
-		triggered, _ := c.banDetector.Detect(guildIndex, actorIndex, timestamp, thresholds.BanThreshold)
+		triggered, _ := c.banDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
 		if triggered {
 			flags = c.flagDetector.SetFlag(flags, detectors.FlagBanTriggered)
 		}
 
+	case ingest.EventTypeKick:
+		triggered, _ := c.kickDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagKickTriggered)
+		}
+
+	case ingest.EventTypeMemberPrune:
+		// Metadata carries members_removed from the audit entry
+		triggered, _ := c.pruneDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, uint32(event.Metadata), limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagPruneTriggered)
+		}
+
+	case ingest.EventTypeMemberUpdate:
+		// Metadata carries the granted role ID
+		adminGrant := event.Flags&ingest.EventFlagAdminGrant != 0
+		triggered, _ := c.roleGrantDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, adminGrant, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagPermissionTriggered)
+		}
+
+	case ingest.EventTypePermChange:
+		// Only role updates that added critical permissions count
+		if escalated {
+			adminGrant := event.Metadata&^previousPerms&detectors.PermAdministrator != 0
+			triggered, _ := c.roleGrantDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, adminGrant, limit.MaxActions, limit.WindowMs)
+			if triggered {
+				flags = c.flagDetector.SetFlag(flags, detectors.FlagPermissionTriggered)
+			}
+		}
+
+	case ingest.EventTypeChannelOverwrite:
+		// Metadata carries the allow bits the change added; only critical
+		// grants to @everyone or broad roles count
+		if event.Flags&ingest.EventFlagCriticalOverwrite != 0 {
+			triggered, _ := c.overwriteDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+			if triggered {
+				flags = c.flagDetector.SetFlag(flags, detectors.FlagOverwriteTriggered)
+			}
+		}
+
+	case ingest.EventTypeServerUpdate:
+		// Metadata carries the mask of changed settings fields
+		triggered, _ := c.guildUpdateDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, event.Metadata, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagGuildUpdateTriggered)
+		}
+
+	case ingest.EventTypeEmojiStickerCreate, ingest.EventTypeEmojiStickerUpdate, ingest.EventTypeEmojiStickerDelete:
+		// Metadata carries the asset kind; emojis and stickers share one limit.
+		// Creates have their own window, so uploading an emoji pack is not
+		// held against the delete limit
+		triggered, _ := c.windowDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagAssetTriggered)
+		}
+
+	case ingest.EventTypeAutomodRuleCreate, ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+		// Attackers strip the keyword filters right before a spam raid
+		triggered, _ := c.windowDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagAutoModTriggered)
+		}
+
+	case ingest.EventTypeGuildEventCreate, ingest.EventTypeGuildEventUpdate, ingest.EventTypeGuildEventDelete:
+		// Every scheduled event notifies the whole guild, a cheap advertising channel
+		triggered, _ := c.windowDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagGuildEventTriggered)
+		}
+
+	case ingest.EventTypeEveryoneHerePing, ingest.EventTypeRolePing:
+		// Webhook pings arrive attributed to the webhook's creator
+		triggered, _ := c.windowDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagPingTriggered)
+		}
+
+	case ingest.EventTypeWebhook:
+		triggered, _ := c.webhookDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+		if triggered {
+			flags = c.flagDetector.SetFlag(flags, detectors.FlagWebhookTriggered)
+		}
+
 	case ingest.EventTypeChannelCreate:
-		triggered, _ := c.channelDetector.Detect(guildIndex, actorIndex, timestamp, thresholds.ChannelThreshold)
-		fmt.Printf("[CORRELATOR] Channel create detected - triggered=%v, threshold=%d\n", triggered, thresholds.ChannelThreshold)
+		triggered, _ := c.channelDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
+		fmt.Printf("[CORRELATOR] Channel create detected - triggered=%v, threshold=%d\n", triggered, limit.MaxActions)
 		if triggered {
 			flags = c.flagDetector.SetFlag(flags, detectors.FlagChannelTriggered)
 			fmt.Printf("[CORRELATOR] FLAGS SET! Creating alert for actor %d\n", event.ActorID)
 		}
 
 	case ingest.EventTypeChannelDelete:
-		triggered, _ := c.channelDetector.Detect(guildIndex, actorIndex, timestamp, thresholds.ChannelThreshold)
+		triggered, _ := c.channelDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
 		if triggered {
 			flags = c.flagDetector.SetFlag(flags, detectors.FlagChannelTriggered)
 		}
 
 	case ingest.EventTypeRoleCreate:
-		triggered, _ := c.roleDetector.Detect(guildIndex, actorIndex, timestamp, thresholds.RoleThreshold)
+		triggered, _ := c.roleDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
 		if triggered {
 			flags = c.flagDetector.SetFlag(flags, detectors.FlagRoleTriggered)
 		}
 
 	case ingest.EventTypeRoleDelete:
-		triggered, _ := c.roleDetector.Detect(guildIndex, actorIndex, timestamp, thresholds.RoleThreshold)
+		triggered, _ := c.roleDetector.Detect(guildIndex, actorIndex, event.EventType, timestamp, limit.MaxActions, limit.WindowMs)
 		if triggered {
 			flags = c.flagDetector.SetFlag(flags, detectors.FlagRoleTriggered)
 		}
@@ -222,19 +391,30 @@ func (c *Correlator) processEvent(event *ingest.Event, guildMap *state.GuildIDMa
 	if flags != 0 {
 		// Normal mode: set triggered flag and queue alert
 		as.SetTriggered(actorIndex, true)
+	}
 
-		detectionTime := util.NowMono() - detectionStart
+	if flags != 0 || needsRevert {
+		c.queueAlert(event, flags, util.NowMono()-detectionStart, limit, needsRevert, revertMetadata)
+	}
+}
 
-		alert := c.alertQueue.Get()
-		alert.GuildID = event.GuildID
-		alert.ActorID = event.ActorID
-		alert.EventType = event.EventType
-		alert.Flags = flags
-		alert.Timestamp = detectionTime
-		alert.Severity = detectors.GetSeverityFromFlags(flags)
-		alert.PanicMode = 0
-		c.alertQueue.Enqueue(alert)
+func (c *Correlator) queueAlert(event *ingest.Event, flags uint32, detectionTime int64, limit config.EventLimit, revert bool, revertMetadata uint64) {
+	alert := c.alertQueue.Get()
+	alert.GuildID = event.GuildID
+	alert.ActorID = event.ActorID
+	alert.TargetID = event.TargetID
+	alert.EventType = event.EventType
+	alert.Flags = flags
+	alert.Timestamp = detectionTime
+	alert.Severity = detectors.GetSeverityFromFlags(flags)
+	alert.PanicMode = 0
+	alert.Punishment = uint8(limit.Punishment)
+	alert.TimeoutSeconds = limit.TimeoutSeconds
+	if revert {
+		alert.Metadata = revertMetadata
+		alert.Revert = 1
 	}
+	c.alertQueue.Enqueue(alert)
 }
 
 func (c *Correlator) Stop() {
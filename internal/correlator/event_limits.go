@@ -0,0 +1,57 @@
+package correlator
+
+import (
+	"go-antinuke-2.0/internal/config"
+	"go-antinuke-2.0/internal/ingest"
+)
+
+// matrixThreshold maps an event type to its fallback threshold in the guild size matrix.
+func matrixThreshold(matrix config.ThresholdMatrix, eventType uint8) uint32 {
+	switch eventType {
+	case ingest.EventTypeBan:
+		return matrix.BanThreshold
+	case ingest.EventTypeKick:
+		return matrix.KickThreshold
+	case ingest.EventTypeChannelCreate, ingest.EventTypeChannelDelete, ingest.EventTypeChannelUpdate:
+		return matrix.ChannelThreshold
+	case ingest.EventTypeRoleCreate, ingest.EventTypeRoleDelete, ingest.EventTypeRoleUpdate:
+		return matrix.RoleThreshold
+	case ingest.EventTypeWebhook:
+		return matrix.WebhookThreshold
+	case ingest.EventTypePermChange, ingest.EventTypeMemberUpdate:
+		return matrix.PermThreshold
+	case ingest.EventTypeMemberPrune:
+		return matrix.PruneThreshold
+	case ingest.EventTypeChannelOverwrite:
+		return matrix.OverwriteThreshold
+	case ingest.EventTypeServerUpdate:
+		return matrix.GuildUpdateThreshold
+	case ingest.EventTypeEmojiStickerCreate, ingest.EventTypeEmojiStickerUpdate, ingest.EventTypeEmojiStickerDelete:
+		return matrix.EmojiThreshold
+	case ingest.EventTypeAutomodRuleCreate, ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+		return matrix.AutoModThreshold
+	case ingest.EventTypeGuildEventCreate, ingest.EventTypeGuildEventUpdate, ingest.EventTypeGuildEventDelete:
+		return matrix.GuildEventThreshold
+	case ingest.EventTypeEveryoneHerePing, ingest.EventTypeRolePing:
+		return matrix.PingThreshold
+	default:
+		return matrix.VelocityThreshold
+	}
+}
+
+// resolveEventLimit returns the limit the correlator enforces for this event:
+// the guild's configured event_limits row, or the size-matrix default.
+func resolveEventLimit(profile *config.GuildProfile, eventType uint8) config.EventLimit {
+	matrix := config.GetGuildThresholds(profile.GuildID, profile.MemberCount)
+	return config.ResolveLimit(profile, matrix, toggleEventType(eventType), matrixThreshold(matrix, eventType))
+}
+
+// toggleEventType maps an ingest event type to the event_types row that
+// enables, whitelists and limits it. Permission changes have no row of their
+// own and follow role updates.
+func toggleEventType(eventType uint8) uint8 {
+	if eventType == ingest.EventTypePermChange {
+		return ingest.EventTypeRoleUpdate
+	}
+	return eventType
+}
@@ -0,0 +1,17 @@
+package correlator
+
+import (
+	"go-antinuke-2.0/internal/config"
+	"go-antinuke-2.0/internal/state"
+)
+
+// hasWhitelistedRole checks the actor's cached roles against the guild's role
+// whitelist for the event type. Guilds without role whitelists skip the member
+// lookup entirely.
+func hasWhitelistedRole(profileStore *config.ProfileStore, guildID, actorID uint64, eventType uint8) bool {
+	if !profileStore.HasTrustedRoles(guildID) {
+		return false
+	}
+	roles := state.GetMemberRoleCache().Roles(guildID, actorID)
+	return len(roles) > 0 && profileStore.HasTrustedRole(guildID, roles, eventType)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -88,6 +89,10 @@ func Initialize(dbPath string) error {
 		return fmt.Errorf("failed to create tables: %w", err)
 	}
 
+	if err := globalDB.migrateTables(); err != nil {
+		return fmt.Errorf("failed to migrate tables: %w", err)
+	}
+
 	if err := globalDB.seedEventTypes(); err != nil {
 		return fmt.Errorf("failed to seed event types: %w", err)
 	}
@@ -153,6 +158,7 @@ func (d *Database) createTables() error {
 		panic_mode INTEGER DEFAULT 0,
 		log_channel_id TEXT DEFAULT '',
 		enabled_events TEXT DEFAULT '',
+		fake_event_policy TEXT DEFAULT 'log',
 		created_at INTEGER DEFAULT 0,
 		updated_at INTEGER DEFAULT 0
 	);
@@ -195,6 +201,7 @@ func (d *Database) createTables() error {
 		max_actions INTEGER DEFAULT 3,
 		time_window INTEGER DEFAULT 10,
 		punishment TEXT DEFAULT 'ban',
+		timeout_seconds INTEGER DEFAULT 0,
 		created_at INTEGER NOT NULL,
 		updated_at INTEGER NOT NULL,
 		UNIQUE(guild_id, event_type),
@@ -221,7 +228,81 @@ func (d *Database) createTables() error {
 	return err
 }
 
-// seedEventTypes populates the event_types table with all 26 event types
+// migrateTables adds columns introduced after a database was first created
+func (d *Database) migrateTables() error {
+	_, err := d.db.Exec(`ALTER TABLE guild_config ADD COLUMN fake_event_policy TEXT DEFAULT 'log'`)
+	if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+		return err
+	}
+	_, err = d.db.Exec(`ALTER TABLE event_limits ADD COLUMN timeout_seconds INTEGER DEFAULT 0`)
+	if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+		return err
+	}
+
+	// user_version records which one-off data migrations have run
+	var version int
+	if err := d.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version < 1 {
+		// Member prune (28) and channel overwrite (29) came after guilds had
+		// picked their events; turn them on wherever protection is enabled
+		if err := d.enableNewEventTypes(28, 29); err != nil {
+			return err
+		}
+		if _, err := d.db.Exec(`PRAGMA user_version = 1`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enableNewEventTypes appends ids to every non-empty enabled_events list that
+// lacks them. Guilds with protection disabled are left alone.
+func (d *Database) enableNewEventTypes(ids ...int) error {
+	rows, err := d.db.Query(`SELECT guild_id, enabled_events FROM guild_config WHERE enabled_events != ''`)
+	if err != nil {
+		return err
+	}
+	updated := make(map[string]string)
+	for rows.Next() {
+		var guildID, enabled string
+		if err := rows.Scan(&guildID, &enabled); err != nil {
+			rows.Close()
+			return err
+		}
+		present := make(map[string]bool)
+		for _, part := range strings.Split(enabled, ",") {
+			present[strings.TrimSpace(part)] = true
+		}
+		list := enabled
+		for _, id := range ids {
+			if idStr := fmt.Sprint(id); !present[idStr] {
+				list += "," + idStr
+			}
+		}
+		if list != enabled {
+			updated[guildID] = list
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for guildID, enabled := range updated {
+		_, err := d.db.Exec(`UPDATE guild_config SET enabled_events = ?, updated_at = ? WHERE guild_id = ?`,
+			enabled, time.Now().Unix(), guildID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedEventTypes populates the event_types table with all 28 event types.
+// IDs match the ingest event types; permission changes (27) follow role
+// updates and have no row of their own
 func (d *Database) seedEventTypes() error {
 	eventTypes := []struct {
 		id          int
@@ -255,6 +336,8 @@ func (d *Database) seedEventTypes() error {
 		{24, "anti_guild_event_update", "Anti Guild Event Update", "📝"},
 		{25, "anti_guild_event_delete", "Anti Guild Event Delete", "🗓️"},
 		{26, "anti_webhook", "Anti Webhook", "🪝"},
+		{28, "anti_member_prune", "Anti Member Prune", "✂️"},
+		{29, "anti_channel_overwrite", "Anti Channel Overwrite", "🔏"},
 	}
 
 	for _, et := range eventTypes {
@@ -276,7 +359,7 @@ func (d *Database) prepareStatements() error {
 
 	// Prepare guild config query
 	d.stmtGetGuildConfig, err = d.db.Prepare(
-		`SELECT guild_id, panic_mode, log_channel_id, enabled_events, created_at, updated_at 
+		`SELECT guild_id, panic_mode, log_channel_id, enabled_events, fake_event_policy, created_at, updated_at 
 		 FROM guild_config WHERE guild_id = ?`,
 	)
 	if err != nil {
@@ -312,26 +395,27 @@ func (d *Database) GetGuildConfig(guildID string) (*GuildConfig, error) {
 	if d.stmtGetGuildConfig != nil {
 		err = d.stmtGetGuildConfig.QueryRow(guildID).Scan(
 			&config.GuildID, &config.PanicMode, &config.LogChannelID, 
-			&config.EnabledEvents, &config.CreatedAt, &config.UpdatedAt,
+			&config.EnabledEvents, &config.FakeEventPolicy, &config.CreatedAt, &config.UpdatedAt,
 		)
 	} else {
 		// Fallback if prepared statement not available
 		err = d.db.QueryRow(
-			`SELECT guild_id, panic_mode, log_channel_id, enabled_events, created_at, updated_at 
+			`SELECT guild_id, panic_mode, log_channel_id, enabled_events, fake_event_policy, created_at, updated_at 
 			 FROM guild_config WHERE guild_id = ?`,
 			guildID,
-		).Scan(&config.GuildID, &config.PanicMode, &config.LogChannelID, &config.EnabledEvents, &config.CreatedAt, &config.UpdatedAt)
+		).Scan(&config.GuildID, &config.PanicMode, &config.LogChannelID, &config.EnabledEvents, &config.FakeEventPolicy, &config.CreatedAt, &config.UpdatedAt)
 	}
 
 	if err == sql.ErrNoRows {
 		// Return default config if not found
 		return &GuildConfig{
-			GuildID:       guildID,
-			PanicMode:     false,
-			LogChannelID:  "",
-			EnabledEvents: "",
-			CreatedAt:     time.Now().Unix(),
-			UpdatedAt:     time.Now().Unix(),
+			GuildID:         guildID,
+			PanicMode:       false,
+			LogChannelID:    "",
+			EnabledEvents:   "",
+			FakeEventPolicy: "log",
+			CreatedAt:       time.Now().Unix(),
+			UpdatedAt:       time.Now().Unix(),
 		}, nil
 	}
 
@@ -350,9 +434,9 @@ func (d *Database) UpsertGuildConfig(config *GuildConfig) error {
 	}
 
 	_, err := d.db.Exec(
-		`INSERT OR REPLACE INTO guild_config (guild_id, panic_mode, log_channel_id, enabled_events, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?)`,
-		config.GuildID, config.PanicMode, config.LogChannelID, config.EnabledEvents, config.CreatedAt, config.UpdatedAt,
+		`INSERT OR REPLACE INTO guild_config (guild_id, panic_mode, log_channel_id, enabled_events, fake_event_policy, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		config.GuildID, config.PanicMode, config.LogChannelID, config.EnabledEvents, config.FakeEventPolicy, config.CreatedAt, config.UpdatedAt,
 	)
 
 	return err
@@ -482,9 +566,9 @@ func (d *Database) UpsertEventLimit(limit *EventLimit) error {
 	}
 
 	_, err := d.db.Exec(
-		`INSERT OR REPLACE INTO event_limits (guild_id, event_type, max_actions, time_window, punishment, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
-		limit.GuildID, limit.EventType, limit.MaxActions, limit.TimeWindow, limit.Punishment, limit.CreatedAt, limit.UpdatedAt,
+		`INSERT OR REPLACE INTO event_limits (guild_id, event_type, max_actions, time_window, punishment, timeout_seconds, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		limit.GuildID, limit.EventType, limit.MaxActions, limit.TimeWindow, limit.Punishment, limit.TimeoutSeconds, limit.CreatedAt, limit.UpdatedAt,
 	)
 	return err
 }
@@ -493,10 +577,10 @@ func (d *Database) UpsertEventLimit(limit *EventLimit) error {
 func (d *Database) GetEventLimit(guildID string, eventType int) (*EventLimit, error) {
 	var limit EventLimit
 	err := d.db.QueryRow(
-		`SELECT id, guild_id, event_type, max_actions, time_window, punishment, created_at, updated_at
+		`SELECT id, guild_id, event_type, max_actions, time_window, punishment, timeout_seconds, created_at, updated_at
 		 FROM event_limits WHERE guild_id = ? AND event_type = ?`,
 		guildID, eventType,
-	).Scan(&limit.ID, &limit.GuildID, &limit.EventType, &limit.MaxActions, &limit.TimeWindow, &limit.Punishment, &limit.CreatedAt, &limit.UpdatedAt)
+	).Scan(&limit.ID, &limit.GuildID, &limit.EventType, &limit.MaxActions, &limit.TimeWindow, &limit.Punishment, &limit.TimeoutSeconds, &limit.CreatedAt, &limit.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		// Return default limits
@@ -515,7 +599,7 @@ func (d *Database) GetEventLimit(guildID string, eventType int) (*EventLimit, er
 // GetAllEventLimits retrieves all event limits for a guild
 func (d *Database) GetAllEventLimits(guildID string) ([]*EventLimit, error) {
 	rows, err := d.db.Query(
-		`SELECT id, guild_id, event_type, max_actions, time_window, punishment, created_at, updated_at
+		`SELECT id, guild_id, event_type, max_actions, time_window, punishment, timeout_seconds, created_at, updated_at
 		 FROM event_limits WHERE guild_id = ?`,
 		guildID,
 	)
@@ -527,7 +611,7 @@ func (d *Database) GetAllEventLimits(guildID string) ([]*EventLimit, error) {
 	var limits []*EventLimit
 	for rows.Next() {
 		var limit EventLimit
-		if err := rows.Scan(&limit.ID, &limit.GuildID, &limit.EventType, &limit.MaxActions, &limit.TimeWindow, &limit.Punishment, &limit.CreatedAt, &limit.UpdatedAt); err != nil {
+		if err := rows.Scan(&limit.ID, &limit.GuildID, &limit.EventType, &limit.MaxActions, &limit.TimeWindow, &limit.Punishment, &limit.TimeoutSeconds, &limit.CreatedAt, &limit.UpdatedAt); err != nil {
 			return nil, err
 		}
 		limits = append(limits, &limit)
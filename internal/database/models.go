@@ -2,24 +2,26 @@ package database
 
 // GuildConfig represents guild-specific configuration
 type GuildConfig struct {
-	GuildID       string
-	PanicMode     bool
-	LogChannelID  string
-	EnabledEvents string // Comma-separated event IDs
-	CreatedAt     int64
-	UpdatedAt     int64
+	GuildID         string
+	PanicMode       bool
+	LogChannelID    string
+	EnabledEvents   string // Comma-separated event IDs
+	FakeEventPolicy string // off, log or ban
+	CreatedAt       int64
+	UpdatedAt       int64
 }
 
 // EventLimit represents rate limit configuration for an event
 type EventLimit struct {
-	ID         int64
-	GuildID    string
-	EventType  int
-	MaxActions int    // Maximum number of actions allowed
-	TimeWindow int    // Time window in seconds
-	Punishment string // "kick", "ban", "timeout"
-	CreatedAt  int64
-	UpdatedAt  int64
+	ID             int64
+	GuildID        string
+	EventType      int
+	MaxActions     int    // Maximum number of actions allowed
+	TimeWindow     int    // Time window in seconds
+	Punishment     string // "kick", "ban", "timeout"
+	TimeoutSeconds int    // Timeout length in seconds, 0 uses the configured default
+	CreatedAt      int64
+	UpdatedAt      int64
 }
 
 // Whitelist represents whitelisted users/roles for specific events
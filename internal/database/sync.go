@@ -32,10 +32,22 @@ func (d *Database) SyncGuildStateFromDB(guildID string) error {
 	// Sync enabled state - if anti-nuke has events enabled, it's considered enabled
 	profile.Enabled = guildConfig.EnabledEvents != ""
 
+	// Compile the per-event toggles so the correlator can gate detectors
+	store.SetEnabledEvents(guildIDNum, config.ParseEventMask(guildConfig.EnabledEvents))
+
 	// Update the profile in store
 	store.Set(profile)
 
-	return nil
+	// Sync what fake audit log events are answered with
+	store.SetFakeEventPolicy(guildIDNum, config.ParseFakeEventPolicy(guildConfig.FakeEventPolicy))
+
+	// Load whitelists so user and role exemptions survive restarts
+	if err := d.SyncWhitelistToMemory(guildID); err != nil {
+		return err
+	}
+
+	// Load per-event limits so restarts keep enforcing what admins configured
+	return d.SyncThresholdsToMemory(guildID)
 }
 
 // SyncAllGuildsFromDB loads all guild configurations from database and syncs to in-memory store
@@ -132,28 +144,37 @@ func (d *Database) SyncWhitelistToMemory(guildID string) error {
 		return fmt.Errorf("failed to get whitelist: %w", err)
 	}
 
-	// Get profile store
-	store := config.GetProfileStore()
-	profile := store.GetOrCreate(guildIDNum)
-
-	// Clear existing whitelist
-	profile.Whitelist = make([]uint64, 0)
-
-	// Add all whitelisted users/roles
+	// Rows for the same target are merged into one scope mask; users and
+	// roles are matched differently by the correlator, so keep them apart
+	users := make([]config.WhitelistEntry, 0, len(whitelists))
+	roles := make([]config.WhitelistEntry, 0)
 	for _, w := range whitelists {
 		targetIDNum, err := util.StringToUint64(w.TargetID)
 		if err != nil {
 			continue
 		}
-		// Add to in-memory whitelist
-		profile.Whitelist = append(profile.Whitelist, targetIDNum)
+		scope := config.EventScope(w.EventType)
+		if w.TargetType == "role" {
+			roles = mergeWhitelistEntry(roles, targetIDNum, scope)
+		} else {
+			users = mergeWhitelistEntry(users, targetIDNum, scope)
+		}
 	}
 
-	// Update store
-	store.Set(profile)
+	config.GetProfileStore().SetWhitelist(guildIDNum, users, roles)
 	return nil
 }
 
+func mergeWhitelistEntry(entries []config.WhitelistEntry, id, scope uint64) []config.WhitelistEntry {
+	for i := range entries {
+		if entries[i].ID == id {
+			entries[i].Events |= scope
+			return entries
+		}
+	}
+	return append(entries, config.WhitelistEntry{ID: id, Events: scope})
+}
+
 // SyncThresholdsToMemory syncs event limits/thresholds to correlator for real-time enforcement
 func (d *Database) SyncThresholdsToMemory(guildID string) error {
 	// Get all event limits for this guild
@@ -167,20 +188,33 @@ func (d *Database) SyncThresholdsToMemory(guildID string) error {
 		return fmt.Errorf("invalid guild ID: %w", err)
 	}
 
-	// Get profile store
-	store := config.GetProfileStore()
-	profile := store.GetOrCreate(guildIDNum)
-
-	// Update custom thresholds if configured
-	if len(limits) > 0 {
-		// Create custom threshold matrix if needed
-		if profile.CustomThresholds == nil {
-			profile.CustomThresholds = &config.ThresholdMatrix{}
+	// Build a fresh table and swap it in so the correlator sees the change on its next event
+	set := &config.EventLimitSet{}
+	for _, l := range limits {
+		if l.EventType <= 0 || l.EventType >= config.MaxEventTypes {
+			continue
+		}
+		maxActions := l.MaxActions
+		if maxActions < 0 {
+			maxActions = 0
+		}
+		timeWindow := l.TimeWindow
+		if timeWindow <= 0 {
+			timeWindow = 10
+		}
+		timeoutSeconds := l.TimeoutSeconds
+		if timeoutSeconds < 0 || timeoutSeconds > config.MaxTimeoutSeconds {
+			timeoutSeconds = 0
+		}
+		set[l.EventType] = config.EventLimit{
+			MaxActions:     uint32(maxActions),
+			WindowMs:       uint32(timeWindow) * 1000,
+			TimeoutSeconds: uint32(timeoutSeconds),
+			Punishment:     config.ParsePunishment(l.Punishment),
+			Configured:     true,
 		}
-		// Thresholds are automatically loaded by correlator from database
-		// This sync just ensures the profile is aware of custom limits
 	}
 
-	store.Set(profile)
+	config.GetProfileStore().SetEventLimits(guildIDNum, set)
 	return nil
 }
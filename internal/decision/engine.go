@@ -7,6 +7,7 @@ import (
 	"go-antinuke-2.0/internal/config"
 	"go-antinuke-2.0/internal/correlator"
 	"go-antinuke-2.0/internal/forensics"
+	"go-antinuke-2.0/internal/ingest"
 	"go-antinuke-2.0/internal/logging"
 	"go-antinuke-2.0/internal/state"
 	"go-antinuke-2.0/internal/sys"
@@ -14,11 +15,13 @@ import (
 )
 
 type DecisionEngine struct {
-	alertQueue  *correlator.AlertQueue
-	jobQueue    *JobQueue
-	forensicLog *forensics.ForensicLogger
-	running     bool
-	cpuCore     int
+	alertQueue       *correlator.AlertQueue
+	jobQueue         *JobQueue
+	forensicLog      *forensics.ForensicLogger
+	revertRoleGrants bool
+	timeoutSeconds   uint64
+	running          bool
+	cpuCore          int
 }
 
 func NewDecisionEngine(alertQueue *correlator.AlertQueue, jobQueue *JobQueue, cpuCore int) *DecisionEngine {
@@ -29,11 +32,25 @@ func NewDecisionEngine(alertQueue *correlator.AlertQueue, jobQueue *JobQueue, cp
 	}
 
 	return &DecisionEngine{
-		alertQueue:  alertQueue,
-		jobQueue:    jobQueue,
-		forensicLog: forensicLog,
-		running:     false,
-		cpuCore:     cpuCore,
+		alertQueue:     alertQueue,
+		jobQueue:       jobQueue,
+		forensicLog:    forensicLog,
+		timeoutSeconds: DefaultTimeoutSeconds,
+		running:        false,
+		cpuCore:        cpuCore,
+	}
+}
+
+// SetRevertRoleGrants controls whether dangerous role grants are removed again
+func (de *DecisionEngine) SetRevertRoleGrants(enabled bool) {
+	de.revertRoleGrants = enabled
+}
+
+// SetTimeoutSeconds sets how long a "timeout" punishment lasts for limits
+// without their own duration. Values outside Discord's range are ignored.
+func (de *DecisionEngine) SetTimeoutSeconds(seconds int) {
+	if seconds > 0 && seconds <= config.MaxTimeoutSeconds {
+		de.timeoutSeconds = uint64(seconds)
 	}
 }
 
@@ -93,16 +110,20 @@ func (de *DecisionEngine) processAlert(alert *correlator.Alert) *IncidentPacket
 	severity := EvaluateSeverity(alert.Flags, alert.Severity)
 
 	incident := &IncidentPacket{
-		GuildID:    alert.GuildID,
-		ActorID:    alert.ActorID,
-		TargetID:   alert.TargetID,
-		EventType:  alert.EventType,
-		Severity:   severity,
-		Confidence: 95,
-		Timestamp:  alert.Timestamp,
-		Flags:      alert.Flags,
-		SafetyMode: uint8(safetyMode),
-		PanicMode:  alert.PanicMode,
+		GuildID:        alert.GuildID,
+		ActorID:        alert.ActorID,
+		TargetID:       alert.TargetID,
+		EventType:      alert.EventType,
+		Severity:       severity,
+		Confidence:     95,
+		Timestamp:      alert.Timestamp,
+		Flags:          alert.Flags,
+		SafetyMode:     uint8(safetyMode),
+		PanicMode:      alert.PanicMode,
+		Punishment:     alert.Punishment,
+		TimeoutSeconds: alert.TimeoutSeconds,
+		Metadata:       alert.Metadata,
+		Revert:         alert.Revert,
 	}
 
 	return incident
@@ -115,6 +136,23 @@ func (de *DecisionEngine) executeDecision(incident *IncidentPacket) {
 	shouldLockdown := config.ShouldAutoLockdown(safetyMode) && incident.Severity >= uint8(SeverityCritical)
 	shouldQuarantine := config.ShouldQuarantine(safetyMode) && incident.Severity >= uint8(SeverityMedium)
 
+	// Undo dangerous grants regardless of mode or score; the grant itself is
+	// the damage, whether or not the actor gets punished for it
+	if incident.Revert == 1 {
+		de.revertIncident(incident)
+	}
+
+	// Below-limit grants only needed the revert above
+	if incident.Flags == 0 {
+		return
+	}
+
+	// Mass pings are deleted whatever happens to the author; a webhook
+	// author cannot be banned, and the pings keep notifying until removed
+	if incident.EventType == ingest.EventTypeEveryoneHerePing || incident.EventType == ingest.EventTypeRolePing {
+		de.jobQueue.Enqueue(NewMessagePurgeJob(incident.GuildID, incident.ActorID, "Anti-Nuke - Mass Ping Removed"))
+	}
+
 	// PANIC MODE: ONLY BAN - fastest action possible
 	// No kick, no lockdown, no quarantine, no integration deletion
 	// Discord will automatically clean up integrations when user is banned
@@ -125,10 +163,7 @@ func (de *DecisionEngine) executeDecision(incident *IncidentPacket) {
 
 		as := state.GetActorState()
 		actorMap := state.GetActorIDMap()
-		actorIndex := actorMap.GetIndex(incident.ActorID)
-		if actorIndex == 0 {
-			actorIndex = actorMap.Register(incident.ActorID)
-		}
+		actorIndex := actorMap.Register(incident.GuildID, incident.ActorID)
 		as.SetBanned(actorIndex, true)
 
 		reason := fmt.Sprintf("Panic Mode - %s - Instant Ban Enforced", de.getEventName(incident.EventType))
@@ -149,11 +184,33 @@ func (de *DecisionEngine) executeDecision(incident *IncidentPacket) {
 	if de.forensicLog != nil {
 		eventType := "unknown"
 		switch incident.EventType {
-		case 0:
+		case ingest.EventTypeBan:
 			eventType = "ban"
-		case 1:
+		case ingest.EventTypeKick:
+			eventType = "kick"
+		case ingest.EventTypeMemberPrune:
+			eventType = "member_prune"
+		case ingest.EventTypeMemberUpdate:
+			eventType = "role_grant"
+		case ingest.EventTypePermChange:
+			eventType = "perm_change"
+		case ingest.EventTypeChannelOverwrite:
+			eventType = "channel_overwrite"
+		case ingest.EventTypeServerUpdate:
+			eventType = "server_update"
+		case ingest.EventTypeEmojiStickerCreate, ingest.EventTypeEmojiStickerUpdate, ingest.EventTypeEmojiStickerDelete:
+			eventType = "emoji_sticker"
+		case ingest.EventTypeAutomodRuleCreate, ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+			eventType = "automod_rule"
+		case ingest.EventTypeGuildEventCreate, ingest.EventTypeGuildEventUpdate, ingest.EventTypeGuildEventDelete:
+			eventType = "guild_event"
+		case ingest.EventTypeEveryoneHerePing, ingest.EventTypeRolePing:
+			eventType = "mass_ping"
+		case ingest.EventTypeRoleUpdate:
+			eventType = "self_role"
+		case ingest.EventTypeChannelDelete:
 			eventType = "channel_delete"
-		case 2:
+		case ingest.EventTypeRoleDelete:
 			eventType = "role_delete"
 		}
 
@@ -177,10 +234,7 @@ func (de *DecisionEngine) executeDecision(incident *IncidentPacket) {
 		as := state.GetActorState()
 		actorMap := state.GetActorIDMap()
 
-		actorIndex := actorMap.GetIndex(incident.ActorID)
-		if actorIndex == 0 {
-			actorIndex = actorMap.Register(incident.ActorID)
-		}
+		actorIndex := actorMap.Register(incident.GuildID, incident.ActorID)
 
 		// In panic mode, ALWAYS queue ban jobs even if already marked as banned
 		// This ensures redundant ban attempts for critical threats
@@ -198,7 +252,23 @@ func (de *DecisionEngine) executeDecision(incident *IncidentPacket) {
 		as.SetBanned(actorIndex, true)
 
 		reason := de.getBanReason(incident)
-		job := NewBanJob(incident.GuildID, incident.ActorID, reason, incident.EventType, incident.PanicMode, incident.Timestamp)
+
+		// Honour the punishment configured for this event type via /setpunishment
+		var job *Job
+		switch config.Punishment(incident.Punishment) {
+		case config.PunishKick:
+			job = NewKickJob(incident.GuildID, incident.ActorID, reason)
+		case config.PunishTimeout:
+			seconds := uint64(incident.TimeoutSeconds)
+			if seconds == 0 {
+				seconds = de.timeoutSeconds
+			}
+			job = NewTimeoutJob(incident.GuildID, incident.ActorID, reason, seconds)
+		default:
+			job = NewBanJob(incident.GuildID, incident.ActorID, reason, incident.EventType, incident.PanicMode, incident.Timestamp)
+		}
+		job.EventType = incident.EventType
+		job.DetectionTime = incident.Timestamp
 		de.jobQueue.Enqueue(job)
 	}
 
@@ -221,23 +291,70 @@ func (de *DecisionEngine) executeDecision(incident *IncidentPacket) {
 	}
 }
 
+// revertIncident queues the job that undoes a dangerous change. Metadata
+// carries the granted role for member updates and the role's previous
+// permissions for permission changes; guild settings are restored from the
+// before-image kept in state.
+func (de *DecisionEngine) revertIncident(incident *IncidentPacket) {
+	switch incident.EventType {
+	case ingest.EventTypeMemberUpdate:
+		if de.revertRoleGrants {
+			job := NewRoleRemoveJob(incident.GuildID, incident.TargetID, incident.Metadata, "Anti-Nuke - Dangerous Role Grant Reverted")
+			de.jobQueue.Enqueue(job)
+		}
+	case ingest.EventTypePermChange:
+		job := NewRolePermissionsJob(incident.GuildID, incident.TargetID, incident.Metadata, "Anti-Nuke - Permission Escalation Reverted")
+		de.jobQueue.Enqueue(job)
+	case ingest.EventTypeServerUpdate:
+		job := NewGuildRestoreJob(incident.GuildID, incident.ActorID, "Anti-Nuke - Guild Settings Change Reverted")
+		de.jobQueue.Enqueue(job)
+	case ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+		job := NewAutoModRestoreJob(incident.GuildID, incident.TargetID, "Anti-Nuke - AutoMod Rule Restored")
+		de.jobQueue.Enqueue(job)
+	}
+}
+
 func (de *DecisionEngine) getBanReason(incident *IncidentPacket) string {
 	eventName := ""
 	switch incident.EventType {
-	case 1:
+	case ingest.EventTypeBan:
 		eventName = "Mass Ban Detection"
-	case 10:
+	case ingest.EventTypeKick:
+		eventName = "Mass Kick Detection"
+	case ingest.EventTypeMemberPrune:
+		eventName = "Member Prune Attack"
+	case ingest.EventTypeChannelCreate:
 		eventName = "Channel Create Spam"
-	case 11:
+	case ingest.EventTypeChannelDelete:
 		eventName = "Channel Delete Attack"
-	case 18:
+	case ingest.EventTypeRoleCreate:
 		eventName = "Role Create Spam"
-	case 19:
+	case ingest.EventTypeRoleDelete:
 		eventName = "Role Delete Attack"
-	case 31:
+	case ingest.EventTypeWebhook:
 		eventName = "Webhook Spam"
-	case 32:
+	case ingest.EventTypePermChange:
 		eventName = "Permission Escalation"
+	case ingest.EventTypeMemberUpdate:
+		eventName = "Dangerous Role Grant"
+	case ingest.EventTypeChannelOverwrite:
+		eventName = "Channel Overwrite Tampering"
+	case ingest.EventTypeServerUpdate:
+		eventName = "Guild Settings Tampering"
+	case ingest.EventTypeEmojiStickerCreate, ingest.EventTypeEmojiStickerUpdate:
+		eventName = "Emoji/Sticker Spam"
+	case ingest.EventTypeEmojiStickerDelete:
+		eventName = "Emoji/Sticker Delete Attack"
+	case ingest.EventTypeAutomodRuleCreate, ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+		eventName = "AutoMod Rule Tampering"
+	case ingest.EventTypeGuildEventCreate, ingest.EventTypeGuildEventUpdate, ingest.EventTypeGuildEventDelete:
+		eventName = "Scheduled Event Spam"
+	case ingest.EventTypeEveryoneHerePing:
+		eventName = "@everyone/@here Mass Ping"
+	case ingest.EventTypeRolePing:
+		eventName = "Role Mass Ping"
+	case ingest.EventTypeRoleUpdate:
+		eventName = "Anti-Nuke Role Tampering"
 	default:
 		eventName = "Malicious Activity"
 	}
@@ -259,20 +376,44 @@ func (de *DecisionEngine) getBanReason(incident *IncidentPacket) string {
 
 func (de *DecisionEngine) getEventName(eventType uint8) string {
 	switch eventType {
-	case 1:
+	case ingest.EventTypeBan:
 		return "Mass Ban Detection"
-	case 10:
+	case ingest.EventTypeKick:
+		return "Mass Kick Detection"
+	case ingest.EventTypeMemberPrune:
+		return "Member Prune Attack"
+	case ingest.EventTypeChannelCreate:
 		return "Channel Create Spam"
-	case 11:
+	case ingest.EventTypeChannelDelete:
 		return "Channel Delete Attack"
-	case 18:
+	case ingest.EventTypeRoleCreate:
 		return "Role Create Spam"
-	case 19:
+	case ingest.EventTypeRoleDelete:
 		return "Role Delete Attack"
-	case 31:
+	case ingest.EventTypeWebhook:
 		return "Webhook Spam"
-	case 32:
+	case ingest.EventTypePermChange:
 		return "Permission Escalation"
+	case ingest.EventTypeMemberUpdate:
+		return "Dangerous Role Grant"
+	case ingest.EventTypeChannelOverwrite:
+		return "Channel Overwrite Tampering"
+	case ingest.EventTypeServerUpdate:
+		return "Guild Settings Tampering"
+	case ingest.EventTypeEmojiStickerCreate, ingest.EventTypeEmojiStickerUpdate:
+		return "Emoji/Sticker Spam"
+	case ingest.EventTypeEmojiStickerDelete:
+		return "Emoji/Sticker Delete Attack"
+	case ingest.EventTypeAutomodRuleCreate, ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+		return "AutoMod Rule Tampering"
+	case ingest.EventTypeGuildEventCreate, ingest.EventTypeGuildEventUpdate, ingest.EventTypeGuildEventDelete:
+		return "Scheduled Event Spam"
+	case ingest.EventTypeEveryoneHerePing:
+		return "@everyone/@here Mass Ping"
+	case ingest.EventTypeRolePing:
+		return "Role Mass Ping"
+	case ingest.EventTypeRoleUpdate:
+		return "Anti-Nuke Role Tampering"
 	default:
 		return "Security Violation"
 	}
@@ -365,8 +506,17 @@ const (
 	JobTypeQuarantine
 	JobTypeLockdown
 	JobTypeRoleRemove
+	JobTypeTimeout
+	JobTypeRolePermissions
+	JobTypeGuildRestore
+	JobTypeAutoModRestore
+	JobTypeMessagePurge
 )
 
+// DefaultTimeoutSeconds is how long an actor is timed out when the configured
+// punishment is "timeout" and neither the limit nor the config sets a duration
+const DefaultTimeoutSeconds = 24 * 60 * 60
+
 func NewBanJob(guildID, userID uint64, reason string, eventType, panicMode uint8, detectionTime int64) *Job {
 	return &Job{
 		Type:          JobTypeBan,
@@ -397,6 +547,17 @@ func NewKickJob(guildID, userID uint64, reason string) *Job {
 	}
 }
 
+// NewTimeoutJob creates a job that times out a member; Data carries the duration in seconds
+func NewTimeoutJob(guildID, userID uint64, reason string, seconds uint64) *Job {
+	return &Job{
+		Type:     JobTypeTimeout,
+		GuildID:  guildID,
+		TargetID: userID,
+		Reason:   reason,
+		Data:     seconds,
+	}
+}
+
 func NewLockdownJob(guildID uint64, reason string) *Job {
 	return &Job{
 		Type:    JobTypeLockdown,
@@ -404,3 +565,59 @@ func NewLockdownJob(guildID uint64, reason string) *Job {
 		Reason:  reason,
 	}
 }
+
+// NewRoleRemoveJob creates a job that takes a role back from a member; Data carries the role ID
+func NewRoleRemoveJob(guildID, userID, roleID uint64, reason string) *Job {
+	return &Job{
+		Type:      JobTypeRoleRemove,
+		EventType: ingest.EventTypeMemberUpdate,
+		GuildID:   guildID,
+		TargetID:  userID,
+		Reason:    reason,
+		Data:      roleID,
+	}
+}
+
+// NewRolePermissionsJob creates a job that restores a role's permissions; Data carries the permission bitset
+func NewRolePermissionsJob(guildID, roleID, permissions uint64, reason string) *Job {
+	return &Job{
+		Type:      JobTypeRolePermissions,
+		EventType: ingest.EventTypePermChange,
+		GuildID:   guildID,
+		TargetID:  roleID,
+		Reason:    reason,
+		Data:      permissions,
+	}
+}
+
+// NewGuildRestoreJob creates a job that restores the guild settings an actor changed; TargetID is the actor
+func NewGuildRestoreJob(guildID, actorID uint64, reason string) *Job {
+	return &Job{
+		Type:      JobTypeGuildRestore,
+		EventType: ingest.EventTypeServerUpdate,
+		GuildID:   guildID,
+		TargetID:  actorID,
+		Reason:    reason,
+	}
+}
+
+// NewAutoModRestoreJob creates a job that restores a disabled or deleted AutoMod rule; TargetID is the rule
+func NewAutoModRestoreJob(guildID, ruleID uint64, reason string) *Job {
+	return &Job{
+		Type:      JobTypeAutoModRestore,
+		EventType: ingest.EventTypeAutomodRuleDelete,
+		GuildID:   guildID,
+		TargetID:  ruleID,
+		Reason:    reason,
+	}
+}
+
+// NewMessagePurgeJob creates a job that deletes an actor's logged ping messages; TargetID is the actor
+func NewMessagePurgeJob(guildID, actorID uint64, reason string) *Job {
+	return &Job{
+		Type:     JobTypeMessagePurge,
+		GuildID:  guildID,
+		TargetID: actorID,
+		Reason:   reason,
+	}
+}
@@ -56,6 +56,12 @@ func CountDestructiveFlags(flags uint32) uint32 {
 	if (flags & detectors.FlagBanTriggered) != 0 {
 		count++
 	}
+	if (flags & detectors.FlagKickTriggered) != 0 {
+		count++
+	}
+	if (flags & detectors.FlagPruneTriggered) != 0 {
+		count++
+	}
 	if (flags & detectors.FlagChannelTriggered) != 0 {
 		count++
 	}
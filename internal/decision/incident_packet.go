@@ -9,10 +9,14 @@ type IncidentPacket struct {
 	Confidence uint8
 	SafetyMode uint8
 	PanicMode  uint8
-	_          [3]byte
+	Punishment uint8
+	Revert     uint8
+	_          [1]byte
 	Flags      uint32
-	Timestamp  int64
-	_          [8]byte
+	// TimeoutSeconds is the length of a "timeout" punishment, 0 for the default
+	TimeoutSeconds uint32
+	Timestamp      int64
+	Metadata       uint64
 }
 
 type IncidentType uint8
@@ -17,8 +17,18 @@ const (
 func EvaluateSeverity(flags uint32, flagCount uint8) uint8 {
 	score := uint32(flagCount) * 10
 
+	// A ban spike past the guild's limit is as destructive as a channel or role wipe
 	if (flags & detectors.FlagBanTriggered) != 0 {
-		score += 30
+		score += 60
+	}
+	if (flags & detectors.FlagKickTriggered) != 0 {
+		score += 60
+	}
+	if (flags & detectors.FlagPruneTriggered) != 0 {
+		score += 60
+	}
+	if (flags & detectors.FlagWebhookTriggered) != 0 {
+		score += 60
 	}
 	if (flags & detectors.FlagChannelTriggered) != 0 {
 		score += 60
@@ -29,6 +39,30 @@ func EvaluateSeverity(flags uint32, flagCount uint8) uint8 {
 	if (flags & detectors.FlagPermissionTriggered) != 0 {
 		score += 50
 	}
+	if (flags & detectors.FlagOverwriteTriggered) != 0 {
+		score += 50
+	}
+	// Vanity theft and settings tampering cost partnered servers as much as a wipe
+	if (flags & detectors.FlagGuildUpdateTriggered) != 0 {
+		score += 60
+	}
+	if (flags & detectors.FlagAssetTriggered) != 0 {
+		score += 50
+	}
+	// Disabling AutoMod is usually the opening move of a spam raid
+	if (flags & detectors.FlagAutoModTriggered) != 0 {
+		score += 50
+	}
+	if (flags & detectors.FlagGuildEventTriggered) != 0 {
+		score += 50
+	}
+	if (flags & detectors.FlagPingTriggered) != 0 {
+		score += 50
+	}
+	// Tampering with our own role disarms every other protection
+	if (flags & detectors.FlagSelfRoleTriggered) != 0 {
+		score += 100
+	}
 	if (flags & detectors.FlagMultiActorTriggered) != 0 {
 		score += 25
 	}
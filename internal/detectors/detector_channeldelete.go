@@ -2,6 +2,7 @@ package detectors
 
 import (
 	"fmt"
+	"time"
 
 	"go-antinuke-2.0/internal/state"
 )
@@ -12,7 +13,7 @@ func NewChannelDeleteDetector() *ChannelDeleteDetector {
 	return &ChannelDeleteDetector{}
 }
 
-func (d *ChannelDeleteDetector) Detect(guildIndex, actorIndex uint32, timestamp int64, threshold uint32) (bool, uint32) {
+func (d *ChannelDeleteDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, threshold, windowMs uint32) (bool, uint32) {
 	gs := state.GetGuildState()
 	as := state.GetActorState()
 
@@ -28,10 +29,14 @@ func (d *ChannelDeleteDetector) Detect(guildIndex, actorIndex uint32, timestamp
 		return true, guildCount
 	}
 
-	// Normal mode: increment first, then check thresholds
+	// Normal mode: record first, then check the rate within the window
 	// This way threshold=1 means "ban after 1 action"
-	guildCount := gs.IncrementChannelDeletes(guildIndex)
-	actorCount := as.IncrementChannelDeletes(actorIndex)
+	gs.IncrementChannelDeletes(guildIndex)
+	as.IncrementChannelDeletes(actorIndex)
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	guildCount := gs.RecordInWindow(guildIndex, eventType, timestamp, windowNs)
+	actorCount := as.RecordInWindow(actorIndex, eventType, timestamp, windowNs)
 
 	guildTrigger := BranchlessGreaterEqual(guildCount, threshold)
 	actorTrigger := BranchlessGreaterEqual(actorCount, threshold)
@@ -9,6 +9,15 @@ const (
 	FlagVelocityTriggered
 	FlagMultiActorTriggered
 	FlagLockdownActive
+	FlagKickTriggered
+	FlagPruneTriggered
+	FlagOverwriteTriggered
+	FlagGuildUpdateTriggered
+	FlagAssetTriggered
+	FlagAutoModTriggered
+	FlagGuildEventTriggered
+	FlagPingTriggered
+	FlagSelfRoleTriggered
 )
 
 type FlagDetector struct{}
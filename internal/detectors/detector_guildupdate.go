@@ -0,0 +1,42 @@
+package detectors
+
+import (
+	"time"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+// GuildUpdateDetector counts changes to critical guild settings per actor. A
+// vanity URL change weighs the whole threshold: a stolen vanity is gone for
+// good once someone else claims it, so one is enough to trigger.
+type GuildUpdateDetector struct{}
+
+func NewGuildUpdateDetector() *GuildUpdateDetector {
+	return &GuildUpdateDetector{}
+}
+
+func (d *GuildUpdateDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, changedFields uint64, threshold, windowMs uint32) (bool, uint32) {
+	as := state.GetActorState()
+
+	// Panic mode (threshold = 0): trigger on EVERY event
+	if threshold == 0 {
+		return true, 1
+	}
+
+	weight := uint32(1)
+	if changedFields&state.GuildFieldVanityCode != 0 {
+		weight = threshold
+	}
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	actorCount := as.RecordWeightedInWindow(actorIndex, eventType, timestamp, windowNs, weight)
+
+	triggered := BranchlessGreaterEqual(actorCount, threshold)
+
+	// CRITICAL: Set triggered flag immediately to prevent race conditions
+	if triggered != 0 {
+		as.SetTriggered(actorIndex, true)
+	}
+
+	return triggered != 0, actorCount
+}
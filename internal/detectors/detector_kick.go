@@ -0,0 +1,42 @@
+package detectors
+
+import (
+	"time"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+type KickDetector struct{}
+
+func NewKickDetector() *KickDetector {
+	return &KickDetector{}
+}
+
+func (d *KickDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, threshold, windowMs uint32) (bool, uint32) {
+	gs := state.GetGuildState()
+	as := state.GetActorState()
+
+	// Panic mode (threshold = 0): trigger on EVERY event
+	if threshold == 0 {
+		guildCount := gs.IncrementKicks(guildIndex)
+		as.IncrementKicks(actorIndex)
+		return true, guildCount
+	}
+
+	// Normal mode: check the rate within the window, lifetime totals are kept for stats only
+	gs.IncrementKicks(guildIndex)
+	as.IncrementKicks(actorIndex)
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	guildCount := gs.RecordInWindow(guildIndex, eventType, timestamp, windowNs)
+	actorCount := as.RecordInWindow(actorIndex, eventType, timestamp, windowNs)
+
+	triggered := BranchlessGreaterEqual(guildCount, threshold) | BranchlessGreaterEqual(actorCount, threshold)
+
+	// CRITICAL: Set triggered flag immediately to prevent race conditions
+	if triggered != 0 {
+		as.SetTriggered(actorIndex, true)
+	}
+
+	return triggered != 0, guildCount
+}
@@ -0,0 +1,39 @@
+package detectors
+
+import (
+	"time"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+// OverwriteDetector counts channel overwrites that grant critical permissions
+// to @everyone or broad roles. Attackers spread these across every channel,
+// so both the actor and the guild as a whole are checked.
+type OverwriteDetector struct{}
+
+func NewOverwriteDetector() *OverwriteDetector {
+	return &OverwriteDetector{}
+}
+
+func (d *OverwriteDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, threshold, windowMs uint32) (bool, uint32) {
+	gs := state.GetGuildState()
+	as := state.GetActorState()
+
+	// Panic mode (threshold = 0): trigger on EVERY event
+	if threshold == 0 {
+		return true, 1
+	}
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	guildCount := gs.RecordInWindow(guildIndex, eventType, timestamp, windowNs)
+	actorCount := as.RecordInWindow(actorIndex, eventType, timestamp, windowNs)
+
+	triggered := BranchlessGreaterEqual(guildCount, threshold) | BranchlessGreaterEqual(actorCount, threshold)
+
+	// CRITICAL: Set triggered flag immediately to prevent race conditions
+	if triggered != 0 {
+		as.SetTriggered(actorIndex, true)
+	}
+
+	return triggered != 0, guildCount
+}
@@ -9,10 +9,22 @@ const (
 	PermBanMembers      uint64 = 1 << 2
 	PermKickMembers     uint64 = 1 << 1
 	PermMentionEveryone uint64 = 1 << 17
+	PermViewAuditLog    uint64 = 1 << 7
+	PermModerateMembers uint64 = 1 << 40
 )
 
 const CriticalPermMask = PermAdministrator | PermManageGuild | PermManageRoles | PermBanMembers
 
+// BanCriticalPermMask holds the permissions the antinuke itself needs to
+// attribute, punish and restore. Losing any of them breaks a response.
+const BanCriticalPermMask = PermAdministrator | PermBanMembers | PermKickMembers | PermModerateMembers |
+	PermManageRoles | PermManageChannels | PermManageWebhooks | PermManageGuild | PermViewAuditLog
+
+// CriticalOverwriteMask holds the critical bits a channel overwrite can grant.
+// Administrator and the guild-wide permissions have no effect per channel;
+// ManageRoles there means managing the channel's own overwrites.
+const CriticalOverwriteMask = PermManageChannels | PermManageRoles | PermManageWebhooks | PermMentionEveryone
+
 type PermissionDetector struct {
 	permCache map[uint64]uint64
 }
@@ -40,6 +52,12 @@ func (d *PermissionDetector) Detect(roleID, newPerms uint64) (bool, uint64) {
 	return criticalAdded != 0, criticalAdded
 }
 
+// Previous returns the last permissions seen for a role.
+func (d *PermissionDetector) Previous(roleID uint64) (uint64, bool) {
+	perms, exists := d.permCache[roleID]
+	return perms, exists
+}
+
 func (d *PermissionDetector) CheckElevation(oldPerms, newPerms uint64) bool {
 	diff := oldPerms ^ newPerms
 	addedPerms := diff & newPerms
@@ -0,0 +1,46 @@
+package detectors
+
+import (
+	"time"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+// PruneDetector weighs each prune by the number of members it removed, so a
+// single call that empties the server trips the same limit as a slow drip.
+type PruneDetector struct{}
+
+func NewPruneDetector() *PruneDetector {
+	return &PruneDetector{}
+}
+
+func (d *PruneDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, membersRemoved, threshold, windowMs uint32) (bool, uint32) {
+	gs := state.GetGuildState()
+	as := state.GetActorState()
+
+	// A prune always removes at least one member as far as counting goes
+	if membersRemoved == 0 {
+		membersRemoved = 1
+	}
+
+	// Panic mode (threshold = 0): trigger on EVERY event
+	if threshold == 0 {
+		return true, gs.AddMemberRemovals(guildIndex, membersRemoved)
+	}
+
+	// Normal mode: lifetime totals are kept for stats only
+	gs.AddMemberRemovals(guildIndex, membersRemoved)
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	guildCount := gs.RecordWeightedInWindow(guildIndex, eventType, timestamp, windowNs, membersRemoved)
+	actorCount := as.RecordWeightedInWindow(actorIndex, eventType, timestamp, windowNs, membersRemoved)
+
+	triggered := BranchlessGreaterEqual(guildCount, threshold) | BranchlessGreaterEqual(actorCount, threshold)
+
+	// CRITICAL: Set triggered flag immediately to prevent race conditions
+	if triggered != 0 {
+		as.SetTriggered(actorIndex, true)
+	}
+
+	return triggered != 0, guildCount
+}
@@ -1,6 +1,8 @@
 package detectors
 
 import (
+	"time"
+
 	"go-antinuke-2.0/internal/state"
 )
 
@@ -10,7 +12,7 @@ func NewRoleDeleteDetector() *RoleDeleteDetector {
 	return &RoleDeleteDetector{}
 }
 
-func (d *RoleDeleteDetector) Detect(guildIndex, actorIndex uint32, timestamp int64, threshold uint32) (bool, uint32) {
+func (d *RoleDeleteDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, threshold, windowMs uint32) (bool, uint32) {
 	gs := state.GetGuildState()
 	as := state.GetActorState()
 
@@ -22,9 +24,13 @@ func (d *RoleDeleteDetector) Detect(guildIndex, actorIndex uint32, timestamp int
 		return true, guildCount
 	}
 
-	// Normal mode: check thresholds
-	guildCount := gs.IncrementRoleDeletes(guildIndex)
-	actorCount := as.IncrementRoleDeletes(actorIndex)
+	// Normal mode: check the rate within the window, lifetime totals are kept for stats only
+	gs.IncrementRoleDeletes(guildIndex)
+	as.IncrementRoleDeletes(actorIndex)
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	guildCount := gs.RecordInWindow(guildIndex, eventType, timestamp, windowNs)
+	actorCount := as.RecordInWindow(actorIndex, eventType, timestamp, windowNs)
 
 	guildTrigger := BranchlessGreaterEqual(guildCount, threshold)
 	actorTrigger := BranchlessGreaterEqual(actorCount, threshold)
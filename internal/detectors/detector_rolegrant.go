@@ -0,0 +1,43 @@
+package detectors
+
+import (
+	"time"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+// RoleGrantDetector counts grants of critical permissions per actor, whether a
+// member receives a dangerous role or a role gains dangerous permissions. An
+// Administrator grant weighs the whole threshold, so a single one is enough
+// to trigger.
+type RoleGrantDetector struct{}
+
+func NewRoleGrantDetector() *RoleGrantDetector {
+	return &RoleGrantDetector{}
+}
+
+func (d *RoleGrantDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, adminGrant bool, threshold, windowMs uint32) (bool, uint32) {
+	as := state.GetActorState()
+
+	// Panic mode (threshold = 0): trigger on EVERY event
+	if threshold == 0 {
+		return true, 1
+	}
+
+	weight := uint32(1)
+	if adminGrant {
+		weight = threshold
+	}
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	actorCount := as.RecordWeightedInWindow(actorIndex, eventType, timestamp, windowNs, weight)
+
+	triggered := BranchlessGreaterEqual(actorCount, threshold)
+
+	// CRITICAL: Set triggered flag immediately to prevent race conditions
+	if triggered != 0 {
+		as.SetTriggered(actorIndex, true)
+	}
+
+	return triggered != 0, actorCount
+}
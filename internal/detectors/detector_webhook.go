@@ -0,0 +1,43 @@
+package detectors
+
+import (
+	"time"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+// WebhookDetector counts webhook creates and updates per actor.
+type WebhookDetector struct{}
+
+func NewWebhookDetector() *WebhookDetector {
+	return &WebhookDetector{}
+}
+
+func (d *WebhookDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, threshold, windowMs uint32) (bool, uint32) {
+	gs := state.GetGuildState()
+	as := state.GetActorState()
+
+	// Panic mode (threshold = 0): trigger on EVERY event
+	if threshold == 0 {
+		gs.IncrementWebhooks(guildIndex)
+		return true, as.IncrementWebhooks(actorIndex)
+	}
+
+	// Normal mode: lifetime totals are kept for stats only
+	gs.IncrementWebhooks(guildIndex)
+	as.IncrementWebhooks(actorIndex)
+
+	// Webhook spam is judged per actor; a busy guild with many integrations
+	// creating webhooks should not push an unrelated moderator over the limit
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	actorCount := as.RecordInWindow(actorIndex, eventType, timestamp, windowNs)
+
+	triggered := BranchlessGreaterEqual(actorCount, threshold)
+
+	// CRITICAL: Set triggered flag immediately to prevent race conditions
+	if triggered != 0 {
+		as.SetTriggered(actorIndex, true)
+	}
+
+	return triggered != 0, actorCount
+}
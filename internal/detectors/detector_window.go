@@ -0,0 +1,38 @@
+package detectors
+
+import (
+	"time"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+// WindowDetector counts an event type per actor in a sliding window. It
+// serves the event types whose limit is a plain per-actor rate: emoji and
+// sticker, AutoMod rule and scheduled event changes, and mass pings. Each
+// event type keeps its own window.
+type WindowDetector struct{}
+
+func NewWindowDetector() *WindowDetector {
+	return &WindowDetector{}
+}
+
+func (d *WindowDetector) Detect(guildIndex, actorIndex uint32, eventType uint8, timestamp int64, threshold, windowMs uint32) (bool, uint32) {
+	as := state.GetActorState()
+
+	// Panic mode (threshold = 0): trigger on EVERY event
+	if threshold == 0 {
+		return true, 1
+	}
+
+	windowNs := int64(windowMs) * int64(time.Millisecond)
+	actorCount := as.RecordInWindow(actorIndex, eventType, timestamp, windowNs)
+
+	triggered := BranchlessGreaterEqual(actorCount, threshold)
+
+	// CRITICAL: Set triggered flag immediately to prevent race conditions
+	if triggered != 0 {
+		as.SetTriggered(actorIndex, true)
+	}
+
+	return triggered != 0, actorCount
+}
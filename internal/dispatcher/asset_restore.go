@@ -0,0 +1,99 @@
+package dispatcher
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"go-antinuke-2.0/internal/forensics"
+)
+
+// ExecuteEmojiCreate re-uploads a backed up emoji under its old name and role
+// restrictions. Discord assigns it a new ID.
+func (bre *BanRequestExecutor) ExecuteEmojiCreate(guildID uint64, meta *forensics.AssetMeta, image []byte, reason string) error {
+	body := map[string]interface{}{
+		"name":  meta.Name,
+		"image": "data:" + meta.ContentType + ";base64," + base64.StdEncoding.EncodeToString(image),
+	}
+	if len(meta.Roles) > 0 {
+		body["roles"] = meta.Roles
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/emojis", guildID)
+	return bre.executeAssetUpload(url, guildID, "application/json", payload, reason)
+}
+
+// ExecuteStickerCreate re-uploads a backed up sticker. The sticker endpoint
+// only takes multipart form data.
+func (bre *BanRequestExecutor) ExecuteStickerCreate(guildID uint64, meta *forensics.AssetMeta, image []byte, reason string) error {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+
+	tags := meta.Tags
+	if tags == "" {
+		tags = meta.Name // tags are required
+	}
+	form.WriteField("name", meta.Name)
+	form.WriteField("description", meta.Description)
+	form.WriteField("tags", tags)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="sticker"`)
+	header.Set("Content-Type", meta.ContentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(image); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/stickers", guildID)
+	return bre.executeAssetUpload(url, guildID, form.FormDataContentType(), buf.Bytes(), reason)
+}
+
+func (bre *BanRequestExecutor) executeAssetUpload(url string, guildID uint64, contentType string, payload []byte, reason string) error {
+	if !bre.rateLimiter.CanExecute("asset", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("POST")
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.SetContentType(contentType)
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+	req.SetBody(payload)
+
+	client := bre.httpPool.GetClient()
+	if err := client.DoTimeout(req, resp, 5*time.Second); err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "asset", guildID)
+
+	statusCode := resp.StatusCode()
+	if statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+
+	return fmt.Errorf("asset upload failed: %d", statusCode)
+}
@@ -0,0 +1,33 @@
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go-antinuke-2.0/internal/state"
+)
+
+// ExecuteAutoModRestore puts an AutoMod rule back from its cached
+// definition: a deleted rule is created again (with a new ID), a disabled
+// one is edited back to how it was.
+func (bre *BanRequestExecutor) ExecuteAutoModRestore(guildID uint64, revert state.AutoModRuleRevert, reason string) error {
+	var rule map[string]interface{}
+	if err := json.Unmarshal(revert.Definition, &rule); err != nil {
+		return fmt.Errorf("bad rule definition: %w", err)
+	}
+
+	// Read-only fields
+	delete(rule, "id")
+	delete(rule, "guild_id")
+	delete(rule, "creator_id")
+
+	if revert.Deleted {
+		url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/auto-moderation/rules", guildID)
+		return bre.executeGuildJSON("POST", url, guildID, rule, reason)
+	}
+
+	// The trigger type cannot be changed on edit
+	delete(rule, "trigger_type")
+	url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/auto-moderation/rules/%d", guildID, revert.RuleID)
+	return bre.executeGuildJSON("PATCH", url, guildID, rule, reason)
+}
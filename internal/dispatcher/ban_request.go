@@ -1,6 +1,7 @@
 package dispatcher
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"go-antinuke-2.0/internal/config"
 	"go-antinuke-2.0/internal/database"
 	"go-antinuke-2.0/internal/logging"
+	"go-antinuke-2.0/internal/state"
 )
 
 type BanRequestExecutor struct {
@@ -133,3 +135,282 @@ func (bre *BanRequestExecutor) ExecuteKick(guildID, userID uint64, reason string
 
 	return fmt.Errorf("kick failed: %d", statusCode)
 }
+
+// ExecuteTimeout applies a communication timeout to a member for the given number of seconds
+func (bre *BanRequestExecutor) ExecuteTimeout(guildID, userID uint64, seconds uint64, reason string) error {
+	if !bre.rateLimiter.CanExecute("member", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/members/%d", guildID, userID)
+	until := time.Now().Add(time.Duration(seconds) * time.Second).UTC().Format(time.RFC3339)
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("PATCH")
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.SetContentType("application/json")
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+	req.SetBodyString(`{"communication_disabled_until":"` + until + `"}`)
+
+	client := bre.httpPool.GetClient()
+	err := client.DoTimeout(req, resp, 1500*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "member", guildID)
+
+	statusCode := resp.StatusCode()
+	if statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+
+	return fmt.Errorf("timeout failed: %d", statusCode)
+}
+
+// ExecuteWebhookDelete removes a webhook. A webhook that is already gone counts as deleted.
+func (bre *BanRequestExecutor) ExecuteWebhookDelete(guildID, webhookID uint64, reason string) error {
+	if !bre.rateLimiter.CanExecute("webhook", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/webhooks/%d", webhookID)
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("DELETE")
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+
+	client := bre.httpPool.GetClient()
+	err := client.DoTimeout(req, resp, 1500*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "webhook", guildID)
+
+	statusCode := resp.StatusCode()
+	if (statusCode >= 200 && statusCode < 300) || statusCode == fasthttp.StatusNotFound {
+		return nil
+	}
+
+	return fmt.Errorf("webhook delete failed: %d", statusCode)
+}
+
+// ExecuteScheduledEventDelete removes a scheduled event. An event that is already gone counts as deleted.
+func (bre *BanRequestExecutor) ExecuteScheduledEventDelete(guildID, eventID uint64, reason string) error {
+	if !bre.rateLimiter.CanExecute("guild_event", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/scheduled-events/%d", guildID, eventID)
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("DELETE")
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+
+	client := bre.httpPool.GetClient()
+	err := client.DoTimeout(req, resp, 1500*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "guild_event", guildID)
+
+	statusCode := resp.StatusCode()
+	if (statusCode >= 200 && statusCode < 300) || statusCode == fasthttp.StatusNotFound {
+		return nil
+	}
+
+	return fmt.Errorf("scheduled event delete failed: %d", statusCode)
+}
+
+// ExecuteBulkDelete deletes messages from one channel, 100 per request.
+// Discord's bulk endpoint needs at least two messages, so a single one is
+// deleted on its own. Messages that are already gone count as deleted.
+func (bre *BanRequestExecutor) ExecuteBulkDelete(guildID, channelID uint64, messageIDs []uint64, reason string) error {
+	for start := 0; start < len(messageIDs); start += 100 {
+		end := start + 100
+		if end > len(messageIDs) {
+			end = len(messageIDs)
+		}
+		chunk := messageIDs[start:end]
+
+		if !bre.rateLimiter.CanExecute("message", guildID) {
+			return fmt.Errorf("rate limited")
+		}
+
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+
+		if len(chunk) == 1 {
+			req.SetRequestURI(fmt.Sprintf("https://discord.com/api/v10/channels/%d/messages/%d", channelID, chunk[0]))
+			req.Header.SetMethod("DELETE")
+		} else {
+			ids := make([]string, len(chunk))
+			for i, id := range chunk {
+				ids[i] = strconv.FormatUint(id, 10)
+			}
+			payload, _ := json.Marshal(map[string][]string{"messages": ids})
+			req.SetRequestURI(fmt.Sprintf("https://discord.com/api/v10/channels/%d/messages/bulk-delete", channelID))
+			req.Header.SetMethod("POST")
+			req.Header.SetContentType("application/json")
+			req.SetBody(payload)
+		}
+		req.Header.Set("Authorization", bre.tokenHeader)
+		req.Header.Set("X-Audit-Log-Reason", reason)
+		req.Header.Set("Connection", "keep-alive")
+
+		client := bre.httpPool.GetClient()
+		err := client.DoTimeout(req, resp, 1500*time.Millisecond)
+		if err == nil {
+			bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "message", guildID)
+		}
+		statusCode := resp.StatusCode()
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(resp)
+
+		if err != nil {
+			return err
+		}
+		if (statusCode < 200 || statusCode >= 300) && statusCode != fasthttp.StatusNotFound {
+			return fmt.Errorf("message delete failed: %d", statusCode)
+		}
+	}
+
+	return nil
+}
+
+// ExecuteRoleRemove takes a role away from a member. A member or role that is already gone counts as removed.
+func (bre *BanRequestExecutor) ExecuteRoleRemove(guildID, userID, roleID uint64, reason string) error {
+	if !bre.rateLimiter.CanExecute("member", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/members/%d/roles/%d", guildID, userID, roleID)
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("DELETE")
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+
+	client := bre.httpPool.GetClient()
+	err := client.DoTimeout(req, resp, 1500*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "member", guildID)
+
+	statusCode := resp.StatusCode()
+	if (statusCode >= 200 && statusCode < 300) || statusCode == fasthttp.StatusNotFound {
+		return nil
+	}
+
+	return fmt.Errorf("role remove failed: %d", statusCode)
+}
+
+// ExecuteRolePermissions overwrites a role's permission bitset. A role that is already gone counts as restored.
+func (bre *BanRequestExecutor) ExecuteRolePermissions(guildID, roleID, permissions uint64, reason string) error {
+	if !bre.rateLimiter.CanExecute("role", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/roles/%d", guildID, roleID)
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("PATCH")
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.SetContentType("application/json")
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+	req.SetBodyString(fmt.Sprintf(`{"permissions":"%d"}`, permissions))
+
+	client := bre.httpPool.GetClient()
+	err := client.DoTimeout(req, resp, 1500*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "role", guildID)
+
+	statusCode := resp.StatusCode()
+	if (statusCode >= 200 && statusCode < 300) || statusCode == fasthttp.StatusNotFound {
+		return nil
+	}
+
+	return fmt.Errorf("role permissions restore failed: %d", statusCode)
+}
+
+// ExecuteOverwriteRestore puts a channel permission overwrite back to its
+// before-image, deleting it if it did not exist. A channel that is already
+// gone counts as restored.
+func (bre *BanRequestExecutor) ExecuteOverwriteRestore(guildID uint64, image state.OverwriteImage, reason string) error {
+	if !bre.rateLimiter.CanExecute("channel", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	url := fmt.Sprintf("https://discord.com/api/v10/channels/%d/permissions/%d", image.ChannelID, image.TargetID)
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	if image.Existed {
+		req.Header.SetMethod("PUT")
+		req.Header.SetContentType("application/json")
+		req.SetBodyString(fmt.Sprintf(`{"allow":"%d","deny":"%d","type":%d}`, image.Allow, image.Deny, image.Type))
+	} else {
+		req.Header.SetMethod("DELETE")
+	}
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+
+	client := bre.httpPool.GetClient()
+	err := client.DoTimeout(req, resp, 1500*time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "channel", guildID)
+
+	statusCode := resp.StatusCode()
+	if (statusCode >= 200 && statusCode < 300) || statusCode == fasthttp.StatusNotFound {
+		return nil
+	}
+
+	return fmt.Errorf("overwrite restore failed: %d", statusCode)
+}
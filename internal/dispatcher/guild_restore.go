@@ -0,0 +1,151 @@
+package dispatcher
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"go-antinuke-2.0/internal/state"
+)
+
+// ExecuteGuildRestore puts the given guild settings fields back to their
+// before-image. Fields that need their own endpoint (vanity URL, MFA level)
+// are restored separately; the first error is returned after all attempts.
+func (bre *BanRequestExecutor) ExecuteGuildRestore(guildID uint64, revert state.GuildSettingsRevert, reason string) error {
+	before := revert.Before
+	fields := revert.Fields
+	var firstErr error
+
+	patch := make(map[string]interface{})
+	if fields&state.GuildFieldName != 0 {
+		patch["name"] = before.Name
+	}
+	if fields&state.GuildFieldVerificationLevel != 0 {
+		patch["verification_level"] = before.VerificationLevel
+	}
+	if fields&state.GuildFieldExplicitContentFilter != 0 {
+		patch["explicit_content_filter"] = before.ExplicitContentFilter
+	}
+	if fields&state.GuildFieldSystemChannel != 0 {
+		patch["system_channel_id"] = nullableID(before.SystemChannelID)
+	}
+	if fields&state.GuildFieldRulesChannel != 0 {
+		patch["rules_channel_id"] = nullableID(before.RulesChannelID)
+	}
+	if fields&state.GuildFieldIcon != 0 {
+		if before.Icon == "" {
+			patch["icon"] = nil
+		} else if icon, err := bre.fetchGuildIcon(guildID, before.Icon); err == nil {
+			patch["icon"] = icon
+		} else {
+			firstErr = fmt.Errorf("icon fetch failed: %w", err)
+		}
+	}
+
+	if len(patch) > 0 {
+		url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d", guildID)
+		if err := bre.executeGuildJSON("PATCH", url, guildID, patch, reason); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if fields&state.GuildFieldVanityCode != 0 {
+		url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/vanity-url", guildID)
+		body := map[string]interface{}{"code": nil}
+		if before.VanityCode != "" {
+			body["code"] = before.VanityCode
+		}
+		if err := bre.executeGuildJSON("PATCH", url, guildID, body, reason); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("vanity restore: %w", err)
+		}
+	}
+
+	// Only the owner may change the MFA requirement, so this usually fails
+	// for a bot; it is still attempted in case the bot owns the guild
+	if fields&state.GuildFieldMFALevel != 0 {
+		url := fmt.Sprintf("https://discord.com/api/v10/guilds/%d/mfa", guildID)
+		body := map[string]interface{}{"level": before.MFALevel}
+		if err := bre.executeGuildJSON("POST", url, guildID, body, reason); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("mfa restore: %w", err)
+		}
+	}
+
+	return firstErr
+}
+
+func (bre *BanRequestExecutor) executeGuildJSON(method, url string, guildID uint64, body map[string]interface{}, reason string) error {
+	if !bre.rateLimiter.CanExecute("guild", guildID) {
+		return fmt.Errorf("rate limited")
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod(method)
+	req.Header.Set("Authorization", bre.tokenHeader)
+	req.Header.SetContentType("application/json")
+	req.Header.Set("X-Audit-Log-Reason", reason)
+	req.Header.Set("Connection", "keep-alive")
+	req.SetBody(payload)
+
+	client := bre.httpPool.GetClient()
+	if err := client.DoTimeout(req, resp, 3*time.Second); err != nil {
+		return err
+	}
+
+	bre.rateLimiter.UpdateFromFastHTTPResponse(resp, "guild", guildID)
+
+	statusCode := resp.StatusCode()
+	if statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+
+	return fmt.Errorf("guild restore failed: %d", statusCode)
+}
+
+// fetchGuildIcon downloads a previous guild icon from the CDN as the data URI
+// the guild edit endpoint expects. The CDN keeps old hashes available.
+func (bre *BanRequestExecutor) fetchGuildIcon(guildID uint64, hash string) (string, error) {
+	ext, mime := "png", "image/png"
+	if strings.HasPrefix(hash, "a_") {
+		ext, mime = "gif", "image/gif"
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(fmt.Sprintf("https://cdn.discordapp.com/icons/%d/%s.%s", guildID, hash, ext))
+	req.Header.SetMethod("GET")
+
+	client := bre.httpPool.GetClient()
+	if err := client.DoTimeout(req, resp, 3*time.Second); err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != fasthttp.StatusOK {
+		return "", fmt.Errorf("cdn returned %d", resp.StatusCode())
+	}
+
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(resp.Body()), nil
+}
+
+// nullableID encodes a snowflake for JSON, with 0 meaning "unset".
+func nullableID(id uint64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return strconv.FormatUint(id, 10)
+}
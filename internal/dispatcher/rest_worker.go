@@ -3,15 +3,22 @@ package dispatcher
 import (
 	"fmt"
 	"runtime"
+	"time"
 
 	"go-antinuke-2.0/internal/database"
 	"go-antinuke-2.0/internal/decision"
+	"go-antinuke-2.0/internal/forensics"
+	"go-antinuke-2.0/internal/ingest"
+	"go-antinuke-2.0/internal/logging"
 	"go-antinuke-2.0/internal/notifier"
 	"go-antinuke-2.0/internal/state"
 	"go-antinuke-2.0/internal/sys"
 	"go-antinuke-2.0/pkg/util"
 )
 
+// assetRestoreWindow is how far back deleted emojis and stickers are restored
+const assetRestoreWindow = 24 * time.Hour
+
 type RESTWorker struct {
 	jobQueue    *decision.JobQueue
 	httpPool    *HTTPPool
@@ -75,24 +82,147 @@ func (rw *RESTWorker) runLoop() {
 	}
 }
 
+// afterPunishment logs a successful ban, kick or timeout and undoes what the
+// punished actor left behind.
+func (rw *RESTWorker) afterPunishment(job *decision.Job, banTimeUS int64) {
+	go rw.sendLogAfterBan(job, banTimeUS)
+	go rw.cleanupWebhooks(job.GuildID, job.TargetID)
+	go rw.cleanupScheduledEvents(job.GuildID, job.TargetID)
+	go rw.restoreOverwrites(job.GuildID, job.TargetID)
+	go rw.restoreAssets(job.GuildID, job.TargetID)
+}
+
 func (rw *RESTWorker) executeJob(job *decision.Job) {
 	switch job.Type {
 	case decision.JobTypeBan:
 		banTime, err := rw.banExecutor.ExecuteBan(job.GuildID, job.TargetID, job.Reason)
 		if err == nil {
-			go rw.sendLogAfterBan(job, banTime)
+			rw.afterPunishment(job, banTime)
 		} else {
 			// Ban failed, unmark actor so we can try again or process new events
-			rw.handleBanFailure(job.TargetID)
+			rw.handleBanFailure(job.GuildID, job.TargetID)
 		}
 	case decision.JobTypeKick:
-		rw.banExecutor.ExecuteKick(job.GuildID, job.TargetID, job.Reason)
+		if err := rw.banExecutor.ExecuteKick(job.GuildID, job.TargetID, job.Reason); err == nil {
+			rw.afterPunishment(job, 0)
+		} else {
+			rw.handleBanFailure(job.GuildID, job.TargetID)
+		}
+	case decision.JobTypeTimeout:
+		if err := rw.banExecutor.ExecuteTimeout(job.GuildID, job.TargetID, job.Data, job.Reason); err == nil {
+			rw.afterPunishment(job, 0)
+		} else {
+			rw.handleBanFailure(job.GuildID, job.TargetID)
+		}
+	case decision.JobTypeRoleRemove:
+		if err := rw.banExecutor.ExecuteRoleRemove(job.GuildID, job.TargetID, job.Data, job.Reason); err != nil {
+			logging.Warn("[DISPATCHER] Failed to revert role %d on member %d in guild %d: %v", job.Data, job.TargetID, job.GuildID, err)
+			return
+		}
+		logging.Info("[DISPATCHER] Reverted dangerous role %d on member %d", job.Data, job.TargetID)
+	case decision.JobTypeRolePermissions:
+		if err := rw.banExecutor.ExecuteRolePermissions(job.GuildID, job.TargetID, job.Data, job.Reason); err != nil {
+			logging.Warn("[DISPATCHER] Failed to restore permissions of role %d in guild %d: %v", job.TargetID, job.GuildID, err)
+			return
+		}
+		logging.Info("[DISPATCHER] Restored permissions of role %d to %d", job.TargetID, job.Data)
+	case decision.JobTypeGuildRestore:
+		revert, ok := state.GetGuildSettingsCache().TakeRevert(job.GuildID, job.TargetID)
+		if !ok {
+			return
+		}
+		if err := rw.banExecutor.ExecuteGuildRestore(job.GuildID, revert, job.Reason); err != nil {
+			logging.Warn("[DISPATCHER] Failed to fully restore guild %d settings changed by %d: %v", job.GuildID, job.TargetID, err)
+			return
+		}
+		logging.Info("[DISPATCHER] Restored guild %d settings changed by actor %d", job.GuildID, job.TargetID)
+	case decision.JobTypeAutoModRestore:
+		revert, ok := state.GetAutoModRuleCache().TakeRevert(job.GuildID, job.TargetID)
+		if !ok {
+			return
+		}
+		if err := rw.banExecutor.ExecuteAutoModRestore(job.GuildID, revert, job.Reason); err != nil {
+			logging.Warn("[DISPATCHER] Failed to restore AutoMod rule %d in guild %d: %v", job.TargetID, job.GuildID, err)
+			return
+		}
+		logging.Info("[DISPATCHER] Restored AutoMod rule %d in guild %d", job.TargetID, job.GuildID)
+	case decision.JobTypeMessagePurge:
+		for channelID, messageIDs := range state.GetPingLog().Take(job.GuildID, job.TargetID) {
+			if err := rw.banExecutor.ExecuteBulkDelete(job.GuildID, channelID, messageIDs, job.Reason); err != nil {
+				logging.Warn("[DISPATCHER] Failed to delete %d ping message(s) in channel %d: %v", len(messageIDs), channelID, err)
+				continue
+			}
+			logging.Info("[DISPATCHER] Deleted %d ping message(s) by actor %d in channel %d", len(messageIDs), job.TargetID, channelID)
+		}
+	}
+}
+
+// cleanupWebhooks deletes the webhooks a punished actor created, since
+// attackers keep spamming through them after they are removed
+func (rw *RESTWorker) cleanupWebhooks(guildID, actorID uint64) {
+	for _, webhookID := range state.GetWebhookRegistry().Take(guildID, actorID) {
+		if err := rw.banExecutor.ExecuteWebhookDelete(guildID, webhookID, "Anti-Nuke - Webhook created by punished actor"); err != nil {
+			logging.Warn("[DISPATCHER] Failed to delete webhook %d in guild %d: %v", webhookID, guildID, err)
+			continue
+		}
+		logging.Info("[DISPATCHER] Deleted webhook %d created by punished actor %d", webhookID, actorID)
+	}
+}
+
+// cleanupScheduledEvents deletes the scheduled events a punished actor
+// created, since each one keeps advertising to every member until removed
+func (rw *RESTWorker) cleanupScheduledEvents(guildID, actorID uint64) {
+	for _, eventID := range state.GetScheduledEventRegistry().Take(guildID, actorID) {
+		if err := rw.banExecutor.ExecuteScheduledEventDelete(guildID, eventID, "Anti-Nuke - Scheduled event created by punished actor"); err != nil {
+			logging.Warn("[DISPATCHER] Failed to delete scheduled event %d in guild %d: %v", eventID, guildID, err)
+			continue
+		}
+		logging.Info("[DISPATCHER] Deleted scheduled event %d created by punished actor %d", eventID, actorID)
+	}
+}
+
+// restoreOverwrites puts back the channel overwrites a punished actor
+// tampered with, from the before-images recorded when they changed them
+func (rw *RESTWorker) restoreOverwrites(guildID, actorID uint64) {
+	for _, image := range state.GetOverwriteRegistry().Take(guildID, actorID) {
+		if err := rw.banExecutor.ExecuteOverwriteRestore(guildID, image, "Anti-Nuke - Overwrite changed by punished actor"); err != nil {
+			logging.Warn("[DISPATCHER] Failed to restore overwrite %d on channel %d: %v", image.TargetID, image.ChannelID, err)
+			continue
+		}
+		logging.Info("[DISPATCHER] Restored overwrite %d on channel %d changed by punished actor %d", image.TargetID, image.ChannelID, actorID)
+	}
+}
+
+// restoreAssets re-uploads the emojis and stickers a punished actor deleted
+// in the last day, from the images backed up to disk
+func (rw *RESTWorker) restoreAssets(guildID, actorID uint64) {
+	since := time.Now().Add(-assetRestoreWindow).UnixNano()
+	backup := forensics.GetAssetBackup()
+
+	for _, change := range forensics.GetRecoveryTracker().TakeDeletedAssets(guildID, actorID, since) {
+		meta, image, err := backup.Load(guildID, change.EntityType, change.EntityID)
+		if err != nil {
+			logging.Warn("[DISPATCHER] No backup of %s %d (%s) in guild %d: %v", change.EntityType, change.EntityID, change.Name, guildID, err)
+			continue
+		}
+
+		reason := "Anti-Nuke - Restoring " + change.EntityType + " deleted by punished actor"
+		if change.EntityType == forensics.AssetKindSticker {
+			err = rw.banExecutor.ExecuteStickerCreate(guildID, meta, image, reason)
+		} else {
+			err = rw.banExecutor.ExecuteEmojiCreate(guildID, meta, image, reason)
+		}
+		if err != nil {
+			logging.Warn("[DISPATCHER] Failed to restore %s %s in guild %d: %v", change.EntityType, meta.Name, guildID, err)
+			continue
+		}
+		logging.Info("[DISPATCHER] Restored %s %s deleted by punished actor %d", change.EntityType, meta.Name, actorID)
 	}
 }
 
-func (rw *RESTWorker) handleBanFailure(actorID uint64) {
+func (rw *RESTWorker) handleBanFailure(guildID, actorID uint64) {
 	actorMap := state.GetActorIDMap()
-	actorIndex := actorMap.GetIndex(actorID)
+	actorIndex := actorMap.GetIndex(guildID, actorID)
 	if actorIndex != 0 {
 		as := state.GetActorState()
 		as.SetBanned(actorIndex, false)
@@ -130,20 +260,44 @@ func (rw *RESTWorker) sendLogAfterBan(job *decision.Job, banTimeUS int64) {
 
 func (rw *RESTWorker) getEventName(eventType uint8) string {
 	switch eventType {
-	case 1:
+	case ingest.EventTypeBan:
 		return "Mass Ban Attack"
-	case 10:
+	case ingest.EventTypeKick:
+		return "Mass Kick Attack"
+	case ingest.EventTypeMemberPrune:
+		return "Member Prune Attack"
+	case ingest.EventTypeChannelCreate:
 		return "Channel Create Spam"
-	case 11:
+	case ingest.EventTypeChannelDelete:
 		return "Channel Delete Attack"
-	case 18:
+	case ingest.EventTypeRoleCreate:
 		return "Role Create Spam"
-	case 19:
+	case ingest.EventTypeRoleDelete:
 		return "Role Delete Attack"
-	case 31:
+	case ingest.EventTypeWebhook:
 		return "Webhook Spam"
-	case 32:
+	case ingest.EventTypePermChange:
 		return "Permission Escalation"
+	case ingest.EventTypeMemberUpdate:
+		return "Dangerous Role Grant"
+	case ingest.EventTypeChannelOverwrite:
+		return "Channel Overwrite Tampering"
+	case ingest.EventTypeServerUpdate:
+		return "Guild Settings Tampering"
+	case ingest.EventTypeEmojiStickerCreate, ingest.EventTypeEmojiStickerUpdate:
+		return "Emoji/Sticker Spam"
+	case ingest.EventTypeEmojiStickerDelete:
+		return "Emoji/Sticker Delete Attack"
+	case ingest.EventTypeAutomodRuleCreate, ingest.EventTypeAutomodRuleUpdate, ingest.EventTypeAutomodRuleDelete:
+		return "AutoMod Rule Tampering"
+	case ingest.EventTypeGuildEventCreate, ingest.EventTypeGuildEventUpdate, ingest.EventTypeGuildEventDelete:
+		return "Scheduled Event Spam"
+	case ingest.EventTypeEveryoneHerePing:
+		return "@everyone/@here Mass Ping"
+	case ingest.EventTypeRolePing:
+		return "Role Mass Ping"
+	case ingest.EventTypeRoleUpdate:
+		return "Anti-Nuke Role Tampering"
 	default:
 		return "Malicious Activity"
 	}
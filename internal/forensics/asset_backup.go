@@ -0,0 +1,102 @@
+package forensics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"go-antinuke-2.0/internal/config"
+)
+
+// Asset kinds backed up to disk
+const (
+	AssetKindEmoji   = "emoji"
+	AssetKindSticker = "sticker"
+)
+
+// AssetMeta is everything besides the image needed to re-upload an emoji or sticker.
+type AssetMeta struct {
+	ID          uint64   `json:"id"`
+	GuildID     uint64   `json:"guild_id"`
+	Kind        string   `json:"kind"`
+	Name        string   `json:"name"`
+	ContentType string   `json:"content_type"`
+	Animated    bool     `json:"animated,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        string   `json:"tags,omitempty"`
+}
+
+// AssetBackup stores emoji and sticker images on disk, one directory per
+// guild and kind, so deleted ones can be re-uploaded after an incident.
+type AssetBackup struct {
+	mu   sync.Mutex
+	root string
+}
+
+var globalAssetBackup *AssetBackup
+
+// InitAssetBackup stores backups in an assets directory under snapshotPath.
+func InitAssetBackup(snapshotPath string) {
+	if snapshotPath == "" {
+		snapshotPath = "./snapshots"
+	}
+	globalAssetBackup = &AssetBackup{root: filepath.Join(snapshotPath, "assets")}
+}
+
+func GetAssetBackup() *AssetBackup {
+	if globalAssetBackup == nil {
+		InitAssetBackup(config.Get().Forensics.SnapshotPath)
+	}
+	return globalAssetBackup
+}
+
+func (ab *AssetBackup) path(guildID uint64, kind string, assetID uint64, ext string) string {
+	return filepath.Join(ab.root, strconv.FormatUint(guildID, 10), kind, strconv.FormatUint(assetID, 10)+ext)
+}
+
+// Has reports whether an asset's image is already on disk.
+func (ab *AssetBackup) Has(guildID uint64, kind string, assetID uint64) bool {
+	_, err := os.Stat(ab.path(guildID, kind, assetID, ".bin"))
+	return err == nil
+}
+
+// Save writes an asset's image and metadata.
+func (ab *AssetBackup) Save(meta *AssetMeta, data []byte) error {
+	metaData, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	ab.mu.Lock()
+	defer ab.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(ab.path(meta.GuildID, meta.Kind, meta.ID, "")), 0755); err != nil {
+		return fmt.Errorf("failed to create asset directory: %w", err)
+	}
+	if err := os.WriteFile(ab.path(meta.GuildID, meta.Kind, meta.ID, ".bin"), data, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(ab.path(meta.GuildID, meta.Kind, meta.ID, ".json"), metaData, 0644)
+}
+
+// Load reads an asset's metadata and image back.
+func (ab *AssetBackup) Load(guildID uint64, kind string, assetID uint64) (*AssetMeta, []byte, error) {
+	metaData, err := os.ReadFile(ab.path(guildID, kind, assetID, ".json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var meta AssetMeta
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(ab.path(guildID, kind, assetID, ".bin"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &meta, data, nil
+}
@@ -5,17 +5,58 @@ import (
 	"time"
 )
 
+// discordEpochMs is the first millisecond of 2015, where snowflake time starts
+const discordEpochMs = 1420070400000
+
+// AuditMatcher pairs gateway events with the audit log entries that explain
+// them. An entry only matches when it names the event's target and was
+// created within the tolerance of the event.
 type AuditMatcher struct {
 	tolerance time.Duration
 }
 
 func NewAuditMatcher() *AuditMatcher {
+	return NewAuditMatcherWithTolerance(2 * time.Second)
+}
+
+// NewAuditMatcherWithTolerance builds a matcher that accepts entries created
+// up to tolerance before or after the event, to absorb gateway lag and clock
+// skew between us and Discord.
+func NewAuditMatcherWithTolerance(tolerance time.Duration) *AuditMatcher {
 	return &AuditMatcher{
-		tolerance: 2 * time.Second,
+		tolerance: tolerance,
+	}
+}
+
+// EntryTime returns when an audit log entry was created, read from its ID.
+func EntryTime(entryID string) (time.Time, bool) {
+	id, err := strconv.ParseUint(entryID, 10, 64)
+	if err != nil || id == 0 {
+		return time.Time{}, false
 	}
+	return time.UnixMilli(int64(id>>22) + discordEpochMs), true
 }
 
+// within reports how far the entry lies from eventTime (unix nanoseconds),
+// and whether that is inside the tolerance.
+func (am *AuditMatcher) within(eventTime int64, entry *AuditLogEntry) (time.Duration, bool) {
+	created, ok := EntryTime(entry.ID)
+	if !ok {
+		return 0, false
+	}
+	delta := time.Duration(eventTime - created.UnixNano())
+	if delta < 0 {
+		delta = -delta
+	}
+	return delta, delta <= am.tolerance
+}
+
+// MatchEvent returns the entry about targetID closest in time to eventTime
+// (unix nanoseconds), or nil when no entry is confidently about this event.
 func (am *AuditMatcher) MatchEvent(eventTime int64, entries []AuditLogEntry, targetID uint64) *AuditLogEntry {
+	var best *AuditLogEntry
+	var bestDelta time.Duration
+
 	for i := range entries {
 		entry := &entries[i]
 
@@ -24,10 +65,16 @@ func (am *AuditMatcher) MatchEvent(eventTime int64, entries []AuditLogEntry, tar
 			continue
 		}
 
-		return entry
+		delta, ok := am.within(eventTime, entry)
+		if !ok {
+			continue
+		}
+		if best == nil || delta < bestDelta {
+			best, bestDelta = entry, delta
+		}
 	}
 
-	return nil
+	return best
 }
 
 func (am *AuditMatcher) FindActor(entries []AuditLogEntry, targetID uint64) uint64 {
@@ -44,15 +91,16 @@ func (am *AuditMatcher) FindActor(entries []AuditLogEntry, targetID uint64) uint
 	return 0
 }
 
+// MatchMultiple returns every entry created within the tolerance of eventTime.
 func (am *AuditMatcher) MatchMultiple(eventTime int64, entries []AuditLogEntry) []*AuditLogEntry {
 	matches := make([]*AuditLogEntry, 0)
-	eventTimeMs := eventTime / 1000000
 
 	for i := range entries {
 		entry := &entries[i]
 
-		_ = eventTimeMs
-		matches = append(matches, entry)
+		if _, ok := am.within(eventTime, entry); ok {
+			matches = append(matches, entry)
+		}
 	}
 
 	return matches
@@ -104,6 +104,24 @@ func (rt *RecoveryTracker) TrackRoleCreate(guildID, roleID, actorID uint64, name
 	rt.changes[guildID] = append(rt.changes[guildID], change)
 }
 
+// TrackAssetDelete records a deleted emoji or sticker; kind is AssetKindEmoji or AssetKindSticker
+func (rt *RecoveryTracker) TrackAssetDelete(guildID, assetID, actorID uint64, kind, name string) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	change := &EntityChange{
+		GuildID:    guildID,
+		EntityID:   assetID,
+		EntityType: kind,
+		Action:     "delete",
+		ActorID:    actorID,
+		Timestamp:  time.Now().UnixNano(),
+		Name:       name,
+	}
+
+	rt.changes[guildID] = append(rt.changes[guildID], change)
+}
+
 func (rt *RecoveryTracker) GetMaliciousChanges(guildID, actorID uint64, since int64) []*EntityChange {
 	rt.mu.RLock()
 	defer rt.mu.RUnlock()
@@ -146,6 +164,29 @@ func (rt *RecoveryTracker) GetCreatedChannels(guildID, actorID uint64, since int
 	return channelIDs
 }
 
+// TakeDeletedAssets removes and returns the emoji and sticker deletions by
+// actorID since the given time, so each is restored at most once
+func (rt *RecoveryTracker) TakeDeletedAssets(guildID, actorID uint64, since int64) []*EntityChange {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	var taken []*EntityChange
+	kept := rt.changes[guildID][:0]
+	for _, change := range rt.changes[guildID] {
+		isAsset := change.EntityType == AssetKindEmoji || change.EntityType == AssetKindSticker
+		if isAsset && change.Action == "delete" && change.ActorID == actorID {
+			if change.Timestamp >= since {
+				taken = append(taken, change)
+			}
+			continue
+		}
+		kept = append(kept, change)
+	}
+	rt.changes[guildID] = kept
+
+	return taken
+}
+
 func (rt *RecoveryTracker) ClearGuildChanges(guildID uint64) {
 	rt.mu.Lock()
 	defer rt.mu.Unlock()
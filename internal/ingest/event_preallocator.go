@@ -34,6 +34,8 @@ const (
 	EventTypeGuildEventDelete
 	EventTypeWebhook
 	EventTypePermChange
+	EventTypeMemberPrune
+	EventTypeChannelOverwrite
 )
 
 type Event struct {
@@ -48,6 +50,28 @@ type Event struct {
 	_         [16]byte
 }
 
+// Event flags
+const (
+	// EventFlagAdminGrant marks a member update that granted an Administrator role
+	EventFlagAdminGrant uint16 = 1 << 0
+
+	// EventFlagCriticalOverwrite marks an overwrite change that granted a
+	// critical permission to @everyone or a broad role
+	EventFlagCriticalOverwrite uint16 = 1 << 1
+
+	// EventFlagRestoreRule marks an AutoMod rule update or delete that
+	// disabled or removed a rule we hold a definition for
+	EventFlagRestoreRule uint16 = 1 << 2
+
+	// EventFlagSelfRole marks a role change that cost the antinuke bot
+	// ban-critical permissions or hierarchy position
+	EventFlagSelfRole uint16 = 1 << 3
+
+	// EventFlagUnresolved marks a permission change whose audit entry could
+	// not be found, so its permissions must not become the role's baseline
+	EventFlagUnresolved uint16 = 1 << 4
+)
+
 // Event pool using sync.Pool for better GC performance
 var eventPool = sync.Pool{
 	New: func() interface{} {
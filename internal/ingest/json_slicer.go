@@ -2,334 +2,228 @@ package ingest
 
 import (
 	"encoding/json"
-	"strconv"
 
 	"go-antinuke-2.0/pkg/util"
 )
 
+// SliceEvent builds an event from a dispatch payload, or returns nil for
+// dispatches the pipeline does not consume.
 func SliceEvent(eventType string, rawData json.RawMessage) *Event {
-	data := []byte(rawData)
-
-	switch eventType {
-	case "GUILD_BAN_ADD":
-		return sliceBanAdd(data)
-	case "GUILD_MEMBER_REMOVE":
-		return sliceMemberRemove(data)
-	case "CHANNEL_DELETE":
-		return sliceChannelDelete(data)
-	case "GUILD_ROLE_DELETE":
-		return sliceRoleDelete(data)
-	case "WEBHOOKS_UPDATE":
-		return sliceWebhookUpdate(data)
-	case "GUILD_ROLE_UPDATE":
-		return sliceRoleUpdate(data)
-	default:
+	event := &Event{}
+	if !SliceInto(eventType, rawData, event) {
 		return nil
 	}
+	return event
 }
 
-func sliceBanAdd(data []byte) *Event {
-	guildID := extractU64Field(data, "guild_id")
-	userID := extractU64Field(data, "user", "id")
-
-	if guildID == 0 || userID == 0 {
-		return nil
-	}
-
-	return &Event{
-		EventType: EventTypeBan,
-		GuildID:   guildID,
-		ActorID:   userID,
-		TargetID:  userID,
-		Timestamp: util.NowMono(),
-		Priority:  3,
-		Flags:     0,
-	}
-}
-
-func sliceMemberRemove(data []byte) *Event {
-	guildID := extractU64Field(data, "guild_id")
-	userID := extractU64Field(data, "user", "id")
-
-	if guildID == 0 || userID == 0 {
-		return nil
-	}
-
-	return &Event{
-		EventType: EventTypeKick,
-		GuildID:   guildID,
-		ActorID:   0,
-		TargetID:  userID,
-		Timestamp: util.NowMono(),
-		Priority:  2,
-		Flags:     0,
-	}
-}
-
-func sliceChannelDelete(data []byte) *Event {
-	guildID := extractU64Field(data, "guild_id")
-	channelID := extractU64Field(data, "id")
-
-	if guildID == 0 || channelID == 0 {
-		return nil
-	}
-
-	return &Event{
-		EventType: EventTypeChannelDelete,
-		GuildID:   guildID,
-		ActorID:   0,
-		TargetID:  channelID,
-		Timestamp: util.NowMono(),
-		Priority:  3,
-		Flags:     0,
-	}
-}
-
-func sliceRoleDelete(data []byte) *Event {
-	guildID := extractU64Field(data, "guild_id")
-	roleID := extractU64Field(data, "role_id")
-
-	if guildID == 0 || roleID == 0 {
-		return nil
-	}
-
-	return &Event{
-		EventType: EventTypeRoleDelete,
-		GuildID:   guildID,
-		ActorID:   0,
-		TargetID:  roleID,
-		Timestamp: util.NowMono(),
-		Priority:  3,
-		Flags:     0,
+// SliceInto fills event from a dispatch payload without allocating and
+// reports whether the dispatch is one the pipeline consumes.
+//
+// Only audit log entries are sliced: they are the one dispatch that names
+// who acted, and the correlator discards events without an actor.
+func SliceInto(eventType string, data []byte, event *Event) bool {
+	switch eventType {
+	case "GUILD_AUDIT_LOG_ENTRY_CREATE":
+		return sliceAuditLogEntry(data, event)
+	default:
+		return false
+	}
+}
+
+// AuditActionEventType maps the audit log actions the gateway reader slices
+// to event types. Actions whose events need more than actor and target, such
+// as role grants or permission diffs, return EventTypeUnknown and are left to
+// the discordgo handlers. Role creates are left there too, as Discord files
+// managed roles under whoever added the bot.
+func AuditActionEventType(action int) uint8 {
+	switch action {
+	case 10: // CHANNEL_CREATE
+		return EventTypeChannelCreate
+	case 12: // CHANNEL_DELETE
+		return EventTypeChannelDelete
+	case 20: // MEMBER_KICK
+		return EventTypeKick
+	case 22: // MEMBER_BAN_ADD
+		return EventTypeBan
+	case 32: // ROLE_DELETE
+		return EventTypeRoleDelete
+	default:
+		return EventTypeUnknown
 	}
 }
 
-func sliceWebhookUpdate(data []byte) *Event {
-	guildID := extractU64Field(data, "guild_id")
-	channelID := extractU64Field(data, "channel_id")
-
-	if guildID == 0 {
-		return nil
-	}
-
-	return &Event{
-		EventType: EventTypeWebhook,
-		GuildID:   guildID,
-		ActorID:   0,
-		TargetID:  channelID,
-		Timestamp: util.NowMono(),
-		Priority:  2,
-		Flags:     0,
+func sliceAuditLogEntry(data []byte, event *Event) bool {
+	eventType := AuditActionEventType(int(parseU64(objectField(data, "action_type"))))
+	if eventType == EventTypeUnknown {
+		return false
 	}
-}
 
-func sliceRoleUpdate(data []byte) *Event {
-	guildID := extractU64Field(data, "guild_id")
-	roleID := extractU64Field(data, "role", "id")
-	permissions := extractU64Field(data, "role", "permissions")
-
-	if guildID == 0 || roleID == 0 {
-		return nil
+	guildID := parseU64(objectField(data, "guild_id"))
+	actorID := parseU64(objectField(data, "user_id"))
+	targetID := parseU64(objectField(data, "target_id"))
+	if guildID == 0 || actorID == 0 || targetID == 0 {
+		return false
 	}
 
-	return &Event{
-		EventType: EventTypePermChange,
+	*event = Event{
+		EventType: eventType,
 		GuildID:   guildID,
-		ActorID:   0,
-		TargetID:  roleID,
-		Metadata:  permissions,
+		ActorID:   actorID,
+		TargetID:  targetID,
 		Timestamp: util.NowMono(),
-		Priority:  2,
-		Flags:     0,
 	}
+	AssignPriority(event)
+	return true
 }
 
 func ExtractOp(data []byte) int {
-	val := extractU64Field(data, "op")
-	return int(val)
+	return int(parseU64(objectField(data, "op")))
 }
 
 func ExtractSeq(data []byte) uint64 {
-	return extractU64Field(data, "s")
+	return parseU64(objectField(data, "s"))
 }
 
+// ExtractType returns the dispatch name. It aliases data, so it is only
+// valid while data is.
 func ExtractType(data []byte) string {
-	return findJSONValue(data, []string{"t"})
+	raw := unquote(objectField(data, "t"))
+	if raw == nil {
+		return ""
+	}
+	return util.BytesToString(raw)
 }
 
+// ExtractData returns the raw "d" value, whatever its JSON type.
 func ExtractData(data []byte) []byte {
-	// This is a bit more complex as "d" can be an object or array.
-	// We need to find "d": and then extract the value.
-	// findJSONValue returns string, but we want []byte of the raw JSON.
-
-	// Re-implement finding "d" to return the raw bytes including braces
-	searchKey := []byte(`"d":`)
-	idx := findBytes(data, searchKey)
-	if idx == -1 {
-		return nil
-	}
-
-	current := data[idx+len(searchKey):]
-	for len(current) > 0 && (current[0] == ' ' || current[0] == '\t' || current[0] == '\n') {
-		current = current[1:]
-	}
+	return objectField(data, "d")
+}
 
-	if len(current) == 0 {
+// objectField returns the raw value of key among the top-level fields of
+// obj, or nil. Nested objects, arrays and strings are skipped whole, so a
+// key that only appears deeper, or inside a string, never matches.
+func objectField(obj []byte, key string) []byte {
+	i := skipSpace(obj, 0)
+	if i >= len(obj) || obj[i] != '{' {
 		return nil
 	}
+	i++
 
-	if current[0] == '{' {
-		end := findMatchingBrace(current)
-		if end != -1 {
-			return current[:end+1]
+	for {
+		i = skipSpace(obj, i)
+		if i >= len(obj) || obj[i] != '"' {
+			return nil
 		}
-	} else if current[0] == '[' {
-		// Handle array if needed, though events are usually objects
-		// For now assuming object as per SliceEvent usage
-		// But let's be safe and implement findMatchingBracket
-		end := findMatchingBracket(current)
-		if end != -1 {
-			return current[:end+1]
+		nameLen := skipValue(obj[i:])
+		if nameLen < 2 {
+			return nil
 		}
-	}
+		name := obj[i+1 : i+nameLen-1]
 
-	return nil
-}
+		i = skipSpace(obj, i+nameLen)
+		if i >= len(obj) || obj[i] != ':' {
+			return nil
+		}
+		i = skipSpace(obj, i+1)
 
-func findMatchingBracket(data []byte) int {
-	depth := 0
-	for i, b := range data {
-		if b == '[' {
-			depth++
-		} else if b == ']' {
-			depth--
-			if depth == 0 {
-				return i
-			}
+		valueLen := skipValue(obj[i:])
+		if valueLen < 0 {
+			return nil
+		}
+		if string(name) == key {
+			return obj[i : i+valueLen]
 		}
-	}
-	return -1
-}
 
-func extractU64Field(data []byte, keys ...string) uint64 {
-	s := findJSONValue(data, keys)
-	if s == "" {
-		return 0
-	}
-	// Fast path: remove quotes if present
-	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
-		s = s[1 : len(s)-1]
-	}
-	// Optimized parsing: use manual parsing for common snowflake IDs
-	if len(s) > 0 && len(s) <= 20 { // Discord snowflake max length
-		val := uint64(0)
-		for i := 0; i < len(s); i++ {
-			c := s[i]
-			if c >= '0' && c <= '9' {
-				val = val*10 + uint64(c-'0')
-			} else {
-				// Fallback to standard parsing for non-numeric
-				val, _ = strconv.ParseUint(s, 10, 64)
-				return val
-			}
+		i = skipSpace(obj, i+valueLen)
+		if i >= len(obj) || obj[i] != ',' {
+			return nil
 		}
-		return val
+		i++
 	}
-	val, _ := strconv.ParseUint(s, 10, 64)
-	return val
 }
 
-func findJSONValue(data []byte, keys []string) string {
-	current := data
-
-	for _, key := range keys {
-		searchKey := []byte(`"` + key + `":`)
-		idx := findBytes(current, searchKey)
-		if idx == -1 {
-			return ""
-		}
-
-		current = current[idx+len(searchKey):]
+// skipValue returns the length of the JSON value data starts with, or -1
+// if it is cut short.
+func skipValue(data []byte) int {
+	if len(data) == 0 {
+		return -1
+	}
 
-		for len(current) > 0 && (current[0] == ' ' || current[0] == '\t' || current[0] == '\n') {
-			current = current[1:]
+	switch data[0] {
+	case '"':
+		for i := 1; i < len(data); i++ {
+			switch data[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
 		}
-
-		if len(current) == 0 {
-			return ""
+		return -1
+
+	case '{', '[':
+		depth := 0
+		for i := 0; i < len(data); i++ {
+			switch data[i] {
+			case '"':
+				n := skipValue(data[i:])
+				if n < 0 {
+					return -1
+				}
+				i += n - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
 		}
+		return -1
 
-		if current[0] == '{' {
-			end := findMatchingBrace(current)
-			if end == -1 {
-				return ""
+	default:
+		for i := 0; i < len(data); i++ {
+			switch data[i] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return i
 			}
-			current = current[:end+1]
-			continue
 		}
+		return len(data)
 	}
-
-	return extractValue(current)
 }
 
-func findBytes(data, search []byte) int {
-	for i := 0; i <= len(data)-len(search); i++ {
-		match := true
-		for j := 0; j < len(search); j++ {
-			if data[i+j] != search[j] {
-				match = false
-				break
-			}
-		}
-		if match {
-			return i
-		}
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
 	}
-	return -1
+	return i
 }
 
-func findMatchingBrace(data []byte) int {
-	depth := 0
-	for i, b := range data {
-		if b == '{' {
-			depth++
-		} else if b == '}' {
-			depth--
-			if depth == 0 {
-				return i
-			}
-		}
+// unquote strips the quotes from a raw JSON string, or returns nil for any
+// other value. Escapes are left as they are; the strings we read have none.
+func unquote(raw []byte) []byte {
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return nil
 	}
-	return -1
+	return raw[1 : len(raw)-1]
 }
 
-func extractValue(data []byte) string {
-	if len(data) == 0 {
-		return ""
+// parseU64 reads a number or a quoted snowflake, returning 0 for anything else.
+func parseU64(raw []byte) uint64 {
+	if s := unquote(raw); s != nil {
+		raw = s
 	}
-
-	if data[0] == '"' {
-		end := 1
-		for end < len(data) && data[end] != '"' {
-			if data[end] == '\\' {
-				end++
-			}
-			end++
-		}
-		if end < len(data) {
-			return util.BytesToString(data[:end+1])
-		}
-		return ""
+	if len(raw) == 0 || len(raw) > 20 {
+		return 0
 	}
 
-	end := 0
-	for end < len(data) {
-		c := data[end]
-		if c == ',' || c == '}' || c == ']' || c == ' ' || c == '\t' || c == '\n' {
-			break
+	val := uint64(0)
+	for _, c := range raw {
+		if c < '0' || c > '9' {
+			return 0
 		}
-		end++
+		val = val*10 + uint64(c-'0')
 	}
-
-	return util.BytesToString(data[:end])
+	return val
 }
@@ -11,13 +11,15 @@ const (
 )
 
 var EventPriorityMap = map[uint8]PriorityLevel{
-	EventTypeUnknown:       PriorityNone,
-	EventTypeBan:           PriorityCritical,
-	EventTypeKick:          PriorityMedium,
-	EventTypeChannelDelete: PriorityCritical,
-	EventTypeRoleDelete:    PriorityCritical,
-	EventTypeWebhook:       PriorityMedium,
-	EventTypePermChange:    PriorityMedium,
+	EventTypeUnknown:          PriorityNone,
+	EventTypeBan:              PriorityCritical,
+	EventTypeKick:             PriorityMedium,
+	EventTypeChannelDelete:    PriorityCritical,
+	EventTypeRoleDelete:       PriorityCritical,
+	EventTypeWebhook:          PriorityMedium,
+	EventTypeMemberPrune:      PriorityCritical,
+	EventTypePermChange:       PriorityMedium,
+	EventTypeChannelOverwrite: PriorityMedium,
 }
 
 func AssignPriority(event *Event) {
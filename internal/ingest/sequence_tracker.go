@@ -1,12 +1,17 @@
 package ingest
 
 import (
+	"sync"
 	"sync/atomic"
 )
 
+// SequenceTracker holds what a gateway connection needs to resume: the last
+// dispatch sequence, the session ID and the URL Discord wants resumes sent to.
 type SequenceTracker struct {
 	sequence  uint64
+	mu        sync.RWMutex
 	sessionID string
+	resumeURL string
 }
 
 func NewSequenceTracker() *SequenceTracker {
@@ -22,14 +27,42 @@ func (st *SequenceTracker) Get() uint64 {
 }
 
 func (st *SequenceTracker) SetSessionID(sessionID string) {
+	st.mu.Lock()
 	st.sessionID = sessionID
+	st.mu.Unlock()
 }
 
 func (st *SequenceTracker) GetSessionID() string {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
 	return st.sessionID
 }
 
+// SetResumeURL records the resume_gateway_url from READY.
+func (st *SequenceTracker) SetResumeURL(url string) {
+	st.mu.Lock()
+	st.resumeURL = url
+	st.mu.Unlock()
+}
+
+func (st *SequenceTracker) GetResumeURL() string {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.resumeURL
+}
+
+// CanResume reports whether a dropped connection can resume its session
+// instead of identifying again.
+func (st *SequenceTracker) CanResume() bool {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.sessionID != "" && st.Get() != 0
+}
+
 func (st *SequenceTracker) Reset() {
 	atomic.StoreUint64(&st.sequence, 0)
+	st.mu.Lock()
 	st.sessionID = ""
+	st.resumeURL = ""
+	st.mu.Unlock()
 }
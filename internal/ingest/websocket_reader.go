@@ -1,179 +1,579 @@
 package ingest
 
 import (
+	"bytes"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"runtime"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"go-antinuke-2.0/internal/logging"
 	"go-antinuke-2.0/internal/sys"
 )
 
+const (
+	gatewayURL   = "wss://gateway.discord.gg"
+	gatewayQuery = "/?v=10&encoding=json"
+
+	// closeResumable is the close code we use to drop a connection while
+	// keeping its session resumable; 1000 and 1001 would end the session.
+	closeResumable = 4000
+
+	maxReconnectBackoff = 60 * time.Second
+)
+
+var (
+	errReconnect      = errors.New("gateway requested a reconnect")
+	errInvalidSession = errors.New("gateway invalidated the session")
+)
+
+// fatalCloseCodes are the close codes reconnecting cannot fix.
+var fatalCloseCodes = map[int]string{
+	4004: "authentication failed",
+	4010: "invalid shard",
+	4011: "sharding required",
+	4012: "invalid API version",
+	4013: "invalid intents",
+	4014: "disallowed intents",
+}
+
+// ActorFilter reports whether an event's actor should be ignored, e.g.
+// because it is a bot. It runs on the read loop and must not block.
+type ActorFilter func(guildID, actorID uint64) bool
+
+// StateHandler is told when the reader's session goes live on READY or
+// RESUMED, and when it stops being live because the connection dropped.
+type StateHandler func(live bool)
+
+// GatewayReader is a lean Discord gateway client that slices the dispatches
+// the pipeline consumes straight into the ring buffer, without the full
+// unmarshal discordgo does for every event. It keeps its session across
+// drops by resuming, answers reconnect and invalid session requests, and
+// treats a missing heartbeat ACK as a dead connection.
 type GatewayReader struct {
+	token      string
+	intents    int
+	compress   bool
+	shardID    int
+	shardCount int
+	eventQueue *RingBuffer
+	cpuCore    int
+	sequence   *SequenceTracker
+	heartbeat  *HeartbeatMonitor
+	filter     ActorFilter
+	onState    StateHandler
+
+	// live is set between READY or RESUMED and the next drop. Only the read
+	// loop touches it.
+	live bool
+
+	// writeMu serialises writes and guards conn and stopHeartbeat, which
+	// are replaced on every reconnect
+	writeMu       sync.Mutex
 	conn          *websocket.Conn
-	token         string
-	intents       int
-	sequenceNum   uint64
-	sessionID     string
-	heartbeatTick *time.Ticker
-	eventQueue    *RingBuffer
-	ctx           context.Context
-	cancel        context.CancelFunc
-	cpuCore       int
+	stopHeartbeat context.CancelFunc
+	reader        *messageReader
+	acked         uint32
+
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
+// NewGatewayReader creates a reader whose read loop is pinned to cpuCore, or
+// left unpinned when cpuCore is negative.
 func NewGatewayReader(token string, intents int, eventQueue *RingBuffer, cpuCore int) *GatewayReader {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &GatewayReader{
 		token:      token,
 		intents:    intents,
 		eventQueue: eventQueue,
+		cpuCore:    cpuCore,
+		sequence:   NewSequenceTracker(),
+		heartbeat:  NewHeartbeatMonitor(),
 		ctx:        ctx,
 		cancel:     cancel,
-		cpuCore:    cpuCore,
 	}
 }
 
+// SetCompression turns on zlib-stream transport compression. It must be
+// called before Connect.
+func (g *GatewayReader) SetCompression(enabled bool) {
+	g.compress = enabled
+}
+
+// SetShard makes the reader identify as one shard of shardCount. It must be
+// called before Connect.
+func (g *GatewayReader) SetShard(shardID, shardCount int) {
+	g.shardID = shardID
+	g.shardCount = shardCount
+}
+
+// SetActorFilter installs the filter sliced events pass before being queued.
+// It must be called before Connect.
+func (g *GatewayReader) SetActorFilter(filter ActorFilter) {
+	g.filter = filter
+}
+
+// SetStateHandler installs the handler told when the session goes live and
+// when it drops. It must be called before Connect.
+func (g *GatewayReader) SetStateHandler(handler StateHandler) {
+	g.onState = handler
+}
+
+// Healthy reports whether heartbeats are being acknowledged.
+func (g *GatewayReader) Healthy() bool {
+	return g.heartbeat.IsHealthy()
+}
+
+// Latency returns the time between the last heartbeat and its ACK.
+func (g *GatewayReader) Latency() time.Duration {
+	return time.Duration(g.heartbeat.GetLatency())
+}
+
+// Connect opens a connection and identifies, or resumes the previous session
+// when there is one.
 func (g *GatewayReader) Connect() error {
-	runtime.LockOSThread()
+	resume := g.sequence.CanResume()
+
+	url := gatewayURL
+	if resumeURL := g.sequence.GetResumeURL(); resume && resumeURL != "" {
+		url = resumeURL
+	}
+	url += gatewayQuery
+	if g.compress {
+		url += "&compress=zlib-stream"
+	}
 
-	// Optimize websocket connection with MASSIVE buffers
 	dialer := &websocket.Dialer{
 		ReadBufferSize:   262144, // 256KB read buffer
 		WriteBufferSize:  131072, // 128KB write buffer
-		Proxy:            nil,
 		HandshakeTimeout: 10 * time.Second,
-		EnableCompression: false, // Disable compression for speed
 	}
-	conn, _, err := dialer.Dial("wss://gateway.discord.gg/?v=10&encoding=json&compress=false", nil)
+	conn, _, err := dialer.DialContext(g.ctx, url, nil)
 	if err != nil {
 		return err
 	}
-	g.conn = conn
 
-	_, msg, err := conn.ReadMessage()
+	reader := newMessageReader(conn, g.compress)
+
+	// The first payload is always HELLO
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	msg, err := reader.next()
 	if err != nil {
-		return err
+		conn.Close()
+		return fmt.Errorf("waiting for hello: %w", err)
 	}
+	conn.SetReadDeadline(time.Time{})
 
-	var hello struct {
-		Op int `json:"op"`
-		D  struct {
-			HeartbeatInterval int `json:"heartbeat_interval"`
-		} `json:"d"`
+	if op := ExtractOp(msg); op != OpHello {
+		conn.Close()
+		return fmt.Errorf("expected hello, got op %d", op)
 	}
-
-	if err := json.Unmarshal(msg, &hello); err != nil {
-		return err
+	interval := time.Duration(parseU64(objectField(ExtractData(msg), "heartbeat_interval"))) * time.Millisecond
+	if interval <= 0 {
+		conn.Close()
+		return fmt.Errorf("hello carried no heartbeat interval")
 	}
 
-	g.heartbeatTick = time.NewTicker(time.Duration(hello.D.HeartbeatInterval) * time.Millisecond)
-	go g.heartbeatLoop()
+	hbCtx, stopHeartbeat := context.WithCancel(g.ctx)
+	g.writeMu.Lock()
+	g.conn = conn
+	g.reader = reader
+	g.stopHeartbeat = stopHeartbeat
+	g.writeMu.Unlock()
+
+	atomic.StoreUint32(&g.acked, 1)
+	go g.heartbeatLoop(hbCtx, interval)
 
+	if resume {
+		return g.sendResume()
+	}
+	return g.sendIdentify()
+}
+
+func (g *GatewayReader) sendIdentify() error {
 	identify := map[string]interface{}{
-		"op": 2,
+		"token":   g.token,
+		"intents": g.intents,
+		"properties": map[string]string{
+			"os":      runtime.GOOS,
+			"browser": "antinuke",
+			"device":  "antinuke",
+		},
+	}
+	if g.shardCount > 1 {
+		identify["shard"] = [2]int{g.shardID, g.shardCount}
+	}
+
+	return g.send(map[string]interface{}{
+		"op": OpIdentify,
+		"d":  identify,
+	})
+}
+
+func (g *GatewayReader) sendResume() error {
+	return g.send(map[string]interface{}{
+		"op": OpResume,
 		"d": map[string]interface{}{
-			"token":   g.token,
-			"intents": g.intents,
-			"properties": map[string]string{
-				"$os":      "linux",
-				"$browser": "antinuke",
-				"$device":  "antinuke",
-			},
+			"token":      g.token,
+			"session_id": g.sequence.GetSessionID(),
+			"seq":        g.sequence.Get(),
 		},
+	})
+}
+
+func (g *GatewayReader) send(payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
 	}
+	return g.write(data)
+}
 
-	return conn.WriteJSON(identify)
+func (g *GatewayReader) write(data []byte) error {
+	g.writeMu.Lock()
+	defer g.writeMu.Unlock()
+	if g.conn == nil {
+		return fmt.Errorf("gateway not connected")
+	}
+	return g.conn.WriteMessage(websocket.TextMessage, data)
 }
 
-func (g *GatewayReader) heartbeatLoop() {
+func (g *GatewayReader) sendHeartbeat() error {
+	var buf [48]byte
+	payload := append(buf[:0], `{"op":1,"d":`...)
+	if seq := g.sequence.Get(); seq != 0 {
+		payload = strconv.AppendUint(payload, seq, 10)
+	} else {
+		payload = append(payload, "null"...)
+	}
+	payload = append(payload, '}')
+
+	g.heartbeat.RecordSent()
+	return g.write(payload)
+}
+
+func (g *GatewayReader) heartbeatLoop(ctx context.Context, interval time.Duration) {
+	// The first beat is jittered so reconnecting clients do not beat in step
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(interval))))
+	defer timer.Stop()
+
 	for {
 		select {
-		case <-g.ctx.Done():
+		case <-ctx.Done():
 			return
-		case <-g.heartbeatTick.C:
-			seq := atomic.LoadUint64(&g.sequenceNum)
-			payload := map[string]interface{}{
-				"op": 1,
-				"d":  seq,
-			}
-			g.conn.WriteJSON(payload)
+		case <-timer.C:
 		}
+
+		// No ACK since the previous beat means the connection is a zombie;
+		// dropping it makes the read loop reconnect and resume
+		if atomic.SwapUint32(&g.acked, 0) == 0 {
+			g.heartbeat.RecordMissed()
+			logging.Warn("[GATEWAY] Heartbeat not acknowledged, reconnecting")
+			g.dropConnection(closeResumable)
+			return
+		}
+
+		if err := g.sendHeartbeat(); err != nil {
+			logging.Warn("[GATEWAY] Failed to send heartbeat: %v", err)
+		}
+		timer.Reset(interval)
 	}
 }
 
+// ReadLoop reads and dispatches payloads until Close, reconnecting with
+// backoff whenever the connection drops. It only returns early on a close
+// code that reconnecting cannot fix.
 func (g *GatewayReader) ReadLoop() error {
-	if err := sys.PinToCore(g.cpuCore); err != nil {
-		fmt.Printf("Failed to pin ingest thread to core %d: %v\n", g.cpuCore, err)
+	if g.cpuCore >= 0 {
+		if err := sys.PinToCore(g.cpuCore); err != nil {
+			fmt.Printf("Failed to pin ingest thread to core %d: %v\n", g.cpuCore, err)
+		}
+		runtime.LockOSThread()
 	}
-	runtime.LockOSThread()
 
+	backoff := time.Second
 	for {
-		select {
-		case <-g.ctx.Done():
+		err := g.readMessages()
+		g.setLive(false)
+		if g.ctx.Err() != nil {
 			return nil
-		default:
 		}
 
-		messageType, data, err := g.conn.ReadMessage()
-		if err != nil {
-			if err == io.EOF || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-				return err
+		var closeErr *websocket.CloseError
+		if errors.As(err, &closeErr) {
+			if reason, fatal := fatalCloseCodes[closeErr.Code]; fatal {
+				g.Close()
+				return fmt.Errorf("gateway closed the connection: %s (%d)", reason, closeErr.Code)
+			}
+			// Invalid sequence and session timeout cannot be resumed
+			if closeErr.Code == 4007 || closeErr.Code == 4009 {
+				g.sequence.Reset()
 			}
-			continue
 		}
 
-		if messageType != websocket.TextMessage {
-			continue
-		}
+		g.dropConnection(closeResumable)
+		logging.Warn("[GATEWAY] Connection lost (%v), reconnecting", err)
+
+		for {
+			select {
+			case <-g.ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
 
-		g.processMessage(data)
+			if err := g.Connect(); err != nil {
+				logging.Warn("[GATEWAY] Reconnect failed: %v", err)
+				backoff *= 2
+				if backoff > maxReconnectBackoff {
+					backoff = maxReconnectBackoff
+				}
+				continue
+			}
+			backoff = time.Second
+			break
+		}
 	}
 }
 
-func (g *GatewayReader) processMessage(data []byte) {
-	op := ExtractOp(data)
+func (g *GatewayReader) readMessages() error {
+	g.writeMu.Lock()
+	reader := g.reader
+	g.writeMu.Unlock()
 
-	// Update sequence number if present
-	s := ExtractSeq(data)
-	if s > 0 {
-		atomic.StoreUint64(&g.sequenceNum, s)
+	for {
+		data, err := reader.next()
+		if err != nil {
+			return err
+		}
+		if err := g.processMessage(data); err != nil {
+			return err
+		}
 	}
+}
+
+func (g *GatewayReader) processMessage(data []byte) error {
+	switch ExtractOp(data) {
+	case OpDispatch:
+		if seq := ExtractSeq(data); seq != 0 {
+			g.sequence.Update(seq)
+		}
 
-	// Dispatch event - optimized fast path
-	if op == 0 {
 		t := ExtractType(data)
-		if t != "" {
-			d := ExtractData(data)
-			if d != nil {
-				event := SliceEvent(t, json.RawMessage(d))
-				if event != nil {
-					// Skip priority check in favor of raw speed
-					g.eventQueue.Enqueue(event)
-				}
+		d := ExtractData(data)
+		switch t {
+		case "READY":
+			g.sequence.SetSessionID(string(unquote(objectField(d, "session_id"))))
+			g.sequence.SetResumeURL(string(unquote(objectField(d, "resume_gateway_url"))))
+			logging.Info("[GATEWAY] Session ready")
+			g.setLive(true)
+		case "RESUMED":
+			logging.Info("[GATEWAY] Session resumed at sequence %d", g.sequence.Get())
+			g.setLive(true)
+		default:
+			// Dispatches replayed before RESUMED happened while we were not
+			// live, so whoever the state handler handed them to has them
+			if !g.live {
+				return nil
+			}
+			var event Event
+			if !SliceInto(t, d, &event) {
+				return nil
+			}
+			if g.filter != nil && g.filter(event.GuildID, event.ActorID) {
+				return nil
 			}
+			g.eventQueue.Enqueue(&event)
 		}
+
+	case OpHeartbeat:
+		return g.sendHeartbeat()
+
+	case OpReconnect:
+		return errReconnect
+
+	case OpInvalidSession:
+		if !bytes.Equal(ExtractData(data), []byte("true")) {
+			g.sequence.Reset()
+		}
+		// Discord asks for a random 1-5s wait before identifying again
+		time.Sleep(time.Second + time.Duration(rand.Int63n(int64(4*time.Second))))
+		return errInvalidSession
+
+	case OpHeartbeatACK:
+		atomic.StoreUint32(&g.acked, 1)
+		g.heartbeat.RecordACK()
 	}
+
+	return nil
 }
 
-func (g *GatewayReader) Close() error {
-	g.cancel()
-	if g.heartbeatTick != nil {
-		g.heartbeatTick.Stop()
+func (g *GatewayReader) setLive(live bool) {
+	if g.live == live {
+		return
 	}
-	if g.conn != nil {
-		return g.conn.Close()
+	g.live = live
+	if g.onState != nil {
+		g.onState(live)
 	}
+}
+
+// dropConnection stops the heartbeat and closes the current connection.
+func (g *GatewayReader) dropConnection(code int) {
+	g.writeMu.Lock()
+	defer g.writeMu.Unlock()
+
+	if g.stopHeartbeat != nil {
+		g.stopHeartbeat()
+		g.stopHeartbeat = nil
+	}
+	if g.conn == nil {
+		return
+	}
+	g.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, ""), time.Now().Add(time.Second))
+	g.conn.Close()
+	g.conn = nil
+}
+
+func (g *GatewayReader) Close() error {
+	g.cancel()
+	g.dropConnection(websocket.CloseNormalClosure)
 	return nil
 }
 
 func (g *GatewayReader) Send(payload interface{}) error {
-	return g.conn.WriteJSON(payload)
+	return g.send(payload)
+}
+
+// frameSource yields a connection's frames; *websocket.Conn is one.
+type frameSource interface {
+	NextReader() (int, io.Reader, error)
+}
+
+// messageReader yields one gateway payload at a time, inflating the
+// connection-wide zlib stream when compression is on. The returned slice is
+// reused and only valid until the next call.
+type messageReader struct {
+	conn    frameSource
+	frames  frameReader
+	inflate io.ReadCloser
+	buf     []byte
+	start   int
+}
+
+func newMessageReader(conn frameSource, compress bool) *messageReader {
+	r := &messageReader{
+		conn: conn,
+		buf:  make([]byte, 0, 64*1024),
+	}
+	if compress {
+		r.frames.conn = conn
+	}
+	return r
+}
+
+func (r *messageReader) next() ([]byte, error) {
+	if r.frames.conn == nil {
+		_, frame, err := r.conn.NextReader()
+		if err != nil {
+			return nil, err
+		}
+		r.buf, err = readAll(frame, r.buf[:0])
+		return r.buf, err
+	}
+	return r.nextInflated()
+}
+
+// nextInflated cuts the next payload out of the inflated stream. Discord
+// ends each payload with a sync flush, so the inflater hands back whole
+// payloads as soon as their last frame arrives.
+func (r *messageReader) nextInflated() ([]byte, error) {
+	if r.inflate == nil {
+		zr, err := zlib.NewReader(&r.frames)
+		if err != nil {
+			return nil, err
+		}
+		r.inflate = zr
+	}
+
+	for {
+		pending := r.buf[r.start:]
+		if i := skipSpace(pending, 0); i < len(pending) {
+			if n := skipValue(pending[i:]); n > 0 {
+				r.start += i + n
+				return pending[i : i+n], nil
+			}
+		}
+
+		// Move the partial payload to the front and read more behind it
+		n := copy(r.buf, pending)
+		r.buf, r.start = r.buf[:n], 0
+		if len(r.buf) == cap(r.buf) {
+			r.buf = append(r.buf, make([]byte, cap(r.buf))...)[:n]
+		}
+
+		read, err := r.inflate.Read(r.buf[n:cap(r.buf)])
+		r.buf = r.buf[:n+read]
+		if err != nil && read == 0 {
+			return nil, err
+		}
+	}
+}
+
+// frameReader presents the websocket's frames as one continuous stream.
+type frameReader struct {
+	conn    frameSource
+	current io.Reader
+}
+
+func (f *frameReader) Read(p []byte) (int, error) {
+	for {
+		if f.current == nil {
+			_, frame, err := f.conn.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			f.current = frame
+		}
+
+		n, err := f.current.Read(p)
+		if err == io.EOF {
+			f.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// readAll appends everything from r to buf, growing it only when full.
+func readAll(r io.Reader, buf []byte) ([]byte, error) {
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			return buf, err
+		}
+	}
 }
 
 type HTTPGatewayInfo struct {
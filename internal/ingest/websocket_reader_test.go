@@ -0,0 +1,143 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"testing"
+)
+
+// fakeFrames replays prepared frames as a websocket connection would.
+type fakeFrames struct {
+	frames [][]byte
+}
+
+func (f *fakeFrames) NextReader() (int, io.Reader, error) {
+	if len(f.frames) == 0 {
+		return 0, nil, io.EOF
+	}
+	frame := f.frames[0]
+	f.frames = f.frames[1:]
+	return 2, bytes.NewReader(frame), nil
+}
+
+// deflateMessages compresses each message into one zlib stream, ending each
+// with a sync flush the way Discord does, and returns one chunk per message.
+func deflateMessages(t *testing.T, messages ...string) [][]byte {
+	t.Helper()
+
+	var stream bytes.Buffer
+	zw := zlib.NewWriter(&stream)
+	chunks := make([][]byte, 0, len(messages))
+	for _, msg := range messages {
+		start := stream.Len()
+		if _, err := zw.Write([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		chunks = append(chunks, append([]byte(nil), stream.Bytes()[start:]...))
+	}
+	return chunks
+}
+
+func readMessages(t *testing.T, r *messageReader, count int) []string {
+	t.Helper()
+
+	got := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		msg, err := r.next()
+		if err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+		got = append(got, string(msg))
+	}
+	return got
+}
+
+func expectMessages(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d messages, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("message %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+var testPayloads = []string{
+	`{"op":10,"d":{"heartbeat_interval":41250}}`,
+	`{"t":"GUILD_AUDIT_LOG_ENTRY_CREATE","s":2,"op":0,"d":{"reason":"} \"{ ]","guild_id":"1"}}`,
+	`{"t":null,"s":null,"op":11,"d":null}`,
+}
+
+func TestNextInflatedOneMessagePerFrame(t *testing.T) {
+	frames := &fakeFrames{frames: deflateMessages(t, testPayloads...)}
+	r := newMessageReader(frames, true)
+
+	expectMessages(t, readMessages(t, r, len(testPayloads)), testPayloads...)
+}
+
+func TestNextInflatedSplitFrames(t *testing.T) {
+	// Cut every message's compressed bytes into frames of a few bytes each
+	var frames [][]byte
+	for _, chunk := range deflateMessages(t, testPayloads...) {
+		for len(chunk) > 0 {
+			n := 3
+			if n > len(chunk) {
+				n = len(chunk)
+			}
+			frames = append(frames, chunk[:n])
+			chunk = chunk[n:]
+		}
+	}
+	r := newMessageReader(&fakeFrames{frames: frames}, true)
+
+	expectMessages(t, readMessages(t, r, len(testPayloads)), testPayloads...)
+}
+
+func TestNextInflatedSeveralMessagesPerInflate(t *testing.T) {
+	// All messages arrive in one frame, so one inflate yields all of them
+	chunks := deflateMessages(t, testPayloads...)
+	r := newMessageReader(&fakeFrames{frames: [][]byte{bytes.Join(chunks, nil)}}, true)
+
+	expectMessages(t, readMessages(t, r, len(testPayloads)), testPayloads...)
+}
+
+func TestNextInflatedGrowsBuffer(t *testing.T) {
+	large := `{"op":0,"d":{"pad":"` + string(bytes.Repeat([]byte("x"), 200*1024)) + `"}}`
+	want := []string{testPayloads[0], large, testPayloads[2]}
+	r := newMessageReader(&fakeFrames{frames: deflateMessages(t, want...)}, true)
+
+	expectMessages(t, readMessages(t, r, len(want)), want...)
+}
+
+func TestSkipValue(t *testing.T) {
+	tests := []struct {
+		data string
+		want int
+	}{
+		{`{"a":1}`, 7},
+		{`{"a":{"b":[1,2]}},{}`, 17},
+		{`{"a":"}"}`, 9},
+		{`{"a":"\"}"}`, 11},
+		{`"x\\"y`, 5},
+		{`[1,[2]]`, 7},
+		{`123,`, 3},
+		{`true}`, 4},
+		{`{"a":1`, -1},
+		{`{"a":"}`, -1},
+		{`"abc`, -1},
+		{``, -1},
+	}
+
+	for _, tt := range tests {
+		if got := skipValue([]byte(tt.data)); got != tt.want {
+			t.Errorf("skipValue(%q) = %d, want %d", tt.data, got, tt.want)
+		}
+	}
+}
@@ -41,6 +41,9 @@ const (
 	EventTypeGuildEventUpdate
 	EventTypeGuildEventDelete
 	EventTypeWebhook
+	EventTypePermChange
+	EventTypeMemberPrune
+	EventTypeChannelOverwrite
 )
 
 func NewEvent() *Event {
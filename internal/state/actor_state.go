@@ -36,6 +36,7 @@ type ActorProfile struct {
 type ActorState struct {
 	counters [MaxActors]ActorCounters
 	profiles [MaxActors]ActorProfile
+	windows  [MaxActors]WindowSet
 }
 
 var globalActorState *ActorState
@@ -61,6 +62,11 @@ func (a *ActorState) IncrementBans(actorIndex uint32) uint32 {
 	return atomic.AddUint32(&a.counters[actorIndex&ActorMask].BanCount, 1)
 }
 
+func (a *ActorState) IncrementKicks(actorIndex uint32) uint32 {
+	atomic.AddUint32(&a.counters[actorIndex&ActorMask].TotalActions, 1)
+	return atomic.AddUint32(&a.counters[actorIndex&ActorMask].KickCount, 1)
+}
+
 func (a *ActorState) GetChannelDeleteCount(actorIndex uint32) uint32 {
 	return atomic.LoadUint32(&a.counters[actorIndex&ActorMask].ChannelDelete)
 }
@@ -80,6 +86,16 @@ func (a *ActorState) IncrementWebhooks(actorIndex uint32) uint32 {
 	return atomic.AddUint32(&a.counters[actorIndex&ActorMask].WebhookCreate, 1)
 }
 
+// RecordInWindow counts an event of the given class and returns the actor's rate within the window
+func (a *ActorState) RecordInWindow(actorIndex uint32, class uint8, now, windowNs int64) uint32 {
+	return a.windows[actorIndex&ActorMask].Add(class, now, windowNs)
+}
+
+// RecordWeightedInWindow counts an event of the given class with weight n
+func (a *ActorState) RecordWeightedInWindow(actorIndex uint32, class uint8, now, windowNs int64, n uint32) uint32 {
+	return a.windows[actorIndex&ActorMask].AddN(class, now, windowNs, n)
+}
+
 func (a *ActorState) UpdateThreatLevel(actorIndex, level uint32) {
 	atomic.StoreUint32(&a.counters[actorIndex&ActorMask].ThreatLevel, level)
 }
@@ -140,59 +156,57 @@ func (a *ActorState) SetWhitelisted(actorIndex uint32, whitelisted bool) {
 	atomic.StoreUint32(&a.profiles[actorIndex&ActorMask].Whitelisted, val)
 }
 
+// claimSlot prepares a slot for a newly registered (guild, actor) pair,
+// dropping anything left behind by an evicted owner.
+func (a *ActorState) claimSlot(actorIndex uint32, guildID, actorID uint64) {
+	a.resetSlot(actorIndex)
+	profile := &a.profiles[actorIndex&ActorMask]
+	atomic.StoreUint64(&profile.ActorID, actorID)
+	atomic.StoreUint64(&profile.GuildID, guildID)
+	atomic.StoreUint32(&profile.Whitelisted, 0)
+	atomic.StoreUint32(&profile.TrustScore, 0)
+}
+
+func (a *ActorState) resetSlot(actorIndex uint32) {
+	counters := &a.counters[actorIndex&ActorMask]
+	atomic.StoreUint32(&counters.BanCount, 0)
+	atomic.StoreUint32(&counters.KickCount, 0)
+	atomic.StoreUint32(&counters.ChannelDelete, 0)
+	atomic.StoreUint32(&counters.RoleDelete, 0)
+	atomic.StoreUint32(&counters.WebhookCreate, 0)
+	atomic.StoreUint32(&counters.PermChange, 0)
+	atomic.StoreUint32(&counters.TotalActions, 0)
+	atomic.StoreUint32(&counters.ThreatLevel, 0)
+	atomic.StoreUint32(&counters.FlagsSet, 0)
+	atomic.StoreUint32(&counters.Banned, 0)
+	atomic.StoreInt64(&counters.LastActionTime, 0)
+	atomic.StoreInt64(&counters.FirstSeenTime, 0)
+	a.windows[actorIndex&ActorMask].Reset()
+}
+
 // ClearGuildActorStates clears all actor state when bot is re-added to guild
 // This ensures previously banned users can be detected again
 func ClearGuildActorStates(guildID uint64) {
 	as := GetActorState()
 	actorMap := GetActorIDMap()
 
-	// Iterate through all registered actors
-	for i := uint32(0); i < MaxActors; i++ {
-		profile := as.GetProfile(i)
-		if profile.GuildID == guildID {
-			// Clear counters
-			counters := as.GetCounters(i)
-			atomic.StoreUint32(&counters.BanCount, 0)
-			atomic.StoreUint32(&counters.KickCount, 0)
-			atomic.StoreUint32(&counters.ChannelDelete, 0)
-			atomic.StoreUint32(&counters.RoleDelete, 0)
-			atomic.StoreUint32(&counters.WebhookCreate, 0)
-			atomic.StoreUint32(&counters.PermChange, 0)
-			atomic.StoreUint32(&counters.TotalActions, 0)
-			atomic.StoreUint32(&counters.ThreatLevel, 0)
-			atomic.StoreUint32(&counters.FlagsSet, 0)
-			atomic.StoreUint32(&counters.Banned, 0)
-			atomic.StoreInt64(&counters.LastActionTime, 0)
-			atomic.StoreInt64(&counters.FirstSeenTime, 0)
+	// Slot 0 is the sentinel and never owned by a guild
+	for i := uint32(1); i < MaxActors; i++ {
+		if atomic.LoadUint64(&as.profiles[i].GuildID) == guildID {
+			as.resetSlot(i)
 		}
 	}
 
-	// Also clear actor ID mapping for this guild
+	// Let the cleared slots be reclaimed first
 	actorMap.ClearGuild(guildID)
 }
 
-// ClearActorState clears state for a specific actor
+// ClearActorState clears an actor's state in one guild
 // This is used when a user is unbanned or rejoins
-func ClearActorState(actorID uint64) {
-	as := GetActorState()
-	actorMap := GetActorIDMap()
-
-	actorIndex := actorMap.GetIndex(actorID)
+func ClearActorState(guildID, actorID uint64) {
+	actorIndex := GetActorIDMap().GetIndex(guildID, actorID)
 	if actorIndex == 0 {
 		return
 	}
-
-	counters := as.GetCounters(actorIndex)
-	atomic.StoreUint32(&counters.BanCount, 0)
-	atomic.StoreUint32(&counters.KickCount, 0)
-	atomic.StoreUint32(&counters.ChannelDelete, 0)
-	atomic.StoreUint32(&counters.RoleDelete, 0)
-	atomic.StoreUint32(&counters.WebhookCreate, 0)
-	atomic.StoreUint32(&counters.PermChange, 0)
-	atomic.StoreUint32(&counters.TotalActions, 0)
-	atomic.StoreUint32(&counters.ThreatLevel, 0)
-	atomic.StoreUint32(&counters.FlagsSet, 0)
-	atomic.StoreUint32(&counters.Banned, 0)
-	atomic.StoreInt64(&counters.LastActionTime, 0)
-	atomic.StoreInt64(&counters.FirstSeenTime, 0)
+	GetActorState().resetSlot(actorIndex)
 }
@@ -0,0 +1,160 @@
+package state
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go-antinuke-2.0/pkg/util"
+)
+
+// ActorProbeLimit bounds linear probing. Every key lives within this many
+// slots of its home slot, so a lookup never scans more than one neighbourhood
+// and a full neighbourhood evicts its least recently seen entry instead of
+// spilling over.
+const ActorProbeLimit = 32
+
+type actorKey struct {
+	guildID uint64
+	actorID uint64
+}
+
+// ActorIDMap maps a (guild, actor) pair to its ActorState slot. It is an
+// open-addressing table sized to MaxActors, so the slot number is the actor
+// index and two live keys never share state. Slot 0 is never handed out and
+// stays the "not found" sentinel. Slots are only ever reused through eviction,
+// never emptied, which keeps the first empty slot a valid end of probe.
+type ActorIDMap struct {
+	mu        sync.RWMutex
+	keys      [MaxActors]actorKey
+	used      [MaxActors]bool
+	lastSeen  [MaxActors]uint64
+	clock     uint64
+	count     uint32
+	evictions uint64
+}
+
+var globalActorIDMap *ActorIDMap
+
+func InitActorIDMap() {
+	globalActorIDMap = &ActorIDMap{}
+}
+
+func GetActorIDMap() *ActorIDMap {
+	return globalActorIDMap
+}
+
+func actorHome(guildID, actorID uint64) uint32 {
+	h := util.HashU64(guildID*0x9E3779B97F4A7C15^actorID) * 0x9E3779B97F4A7C15
+	return uint32(h>>32) & ActorMask
+}
+
+// find returns the slot holding key, or 0. Caller must hold at least a read lock.
+func (a *ActorIDMap) find(key actorKey) uint32 {
+	home := actorHome(key.guildID, key.actorID)
+	for i := uint32(0); i < ActorProbeLimit; i++ {
+		slot := (home + i) & ActorMask
+		if slot == 0 {
+			continue
+		}
+		if !a.used[slot] {
+			return 0
+		}
+		if a.keys[slot] == key {
+			return slot
+		}
+	}
+	return 0
+}
+
+func (a *ActorIDMap) touch(slot uint32) {
+	atomic.StoreUint64(&a.lastSeen[slot], atomic.AddUint64(&a.clock, 1))
+}
+
+// Register returns the actor's slot in the given guild, claiming one if needed.
+// A claimed slot starts with cleared counters and a profile stamped with the
+// owning guild and actor.
+func (a *ActorIDMap) Register(guildID, actorID uint64) uint32 {
+	key := actorKey{guildID: guildID, actorID: actorID}
+
+	a.mu.RLock()
+	slot := a.find(key)
+	if slot != 0 {
+		a.touch(slot)
+	}
+	a.mu.RUnlock()
+	if slot != 0 {
+		return slot
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Another goroutine may have registered the key between the two locks
+	home := actorHome(guildID, actorID)
+	victim := uint32(0)
+	for i := uint32(0); i < ActorProbeLimit; i++ {
+		s := (home + i) & ActorMask
+		if s == 0 {
+			continue
+		}
+		if !a.used[s] {
+			victim = s
+			a.count++
+			break
+		}
+		if a.keys[s] == key {
+			a.touch(s)
+			return s
+		}
+		if victim == 0 || atomic.LoadUint64(&a.lastSeen[s]) < atomic.LoadUint64(&a.lastSeen[victim]) {
+			victim = s
+		}
+	}
+
+	if a.used[victim] {
+		a.evictions++
+	}
+	a.keys[victim] = key
+	a.used[victim] = true
+	a.touch(victim)
+
+	if as := GetActorState(); as != nil {
+		as.claimSlot(victim, guildID, actorID)
+	}
+	return victim
+}
+
+// GetIndex returns the actor's slot in the given guild, or 0 if it has none.
+func (a *ActorIDMap) GetIndex(guildID, actorID uint64) uint32 {
+	a.mu.RLock()
+	slot := a.find(actorKey{guildID: guildID, actorID: actorID})
+	a.mu.RUnlock()
+	return slot
+}
+
+// ClearGuild ages out every slot owned by the guild so they are the first
+// candidates for eviction. The slots keep their keys until reused.
+func (a *ActorIDMap) ClearGuild(guildID uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := uint32(1); i < MaxActors; i++ {
+		if a.used[i] && a.keys[i].guildID == guildID {
+			atomic.StoreUint64(&a.lastSeen[i], 0)
+		}
+	}
+}
+
+// Len returns the number of occupied slots.
+func (a *ActorIDMap) Len() uint32 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.count
+}
+
+// Evictions returns how many slots have been reclaimed from idle actors.
+func (a *ActorIDMap) Evictions() uint64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.evictions
+}
@@ -0,0 +1,103 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// AutoModRevertTTL is how long a deleted or disabled AutoMod rule stays restorable.
+const AutoModRevertTTL = time.Hour
+
+// AutoModRuleRevert is the definition of a rule as it was before an actor
+// disabled or deleted it. Definition is the rule's JSON as Discord sent it.
+type AutoModRuleRevert struct {
+	RuleID     uint64
+	Definition []byte
+	Deleted    bool
+	recorded   time.Time
+}
+
+type autoModRuleKey struct {
+	guildID uint64
+	ruleID  uint64
+}
+
+// AutoModRuleCache keeps each guild's AutoMod rule definitions, so a rule
+// that is disabled or deleted can be put back the way it was.
+type AutoModRuleCache struct {
+	mu      sync.Mutex
+	rules   map[autoModRuleKey][]byte
+	reverts map[autoModRuleKey]AutoModRuleRevert
+}
+
+var globalAutoModRules *AutoModRuleCache
+
+func InitAutoModRuleCache() {
+	globalAutoModRules = &AutoModRuleCache{
+		rules:   make(map[autoModRuleKey][]byte),
+		reverts: make(map[autoModRuleKey]AutoModRuleRevert),
+	}
+}
+
+func GetAutoModRuleCache() *AutoModRuleCache {
+	return globalAutoModRules
+}
+
+// Set stores a rule's definition and returns the previous one, if cached.
+func (c *AutoModRuleCache) Set(guildID, ruleID uint64, definition []byte) (before []byte, known bool) {
+	key := autoModRuleKey{guildID: guildID, ruleID: ruleID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	before, known = c.rules[key]
+	c.rules[key] = definition
+	return before, known
+}
+
+// Remove drops a deleted rule and returns its last cached definition.
+func (c *AutoModRuleCache) Remove(guildID, ruleID uint64) (before []byte, known bool) {
+	key := autoModRuleKey{guildID: guildID, ruleID: ruleID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	before, known = c.rules[key]
+	delete(c.rules, key)
+	return before, known
+}
+
+// RecordRevert remembers a rule's definition before it was disabled or
+// deleted. The oldest definition is kept until taken; a later delete of a
+// disabled rule only marks it deleted.
+func (c *AutoModRuleCache) RecordRevert(guildID, ruleID uint64, definition []byte, deleted bool) {
+	key := autoModRuleKey{guildID: guildID, ruleID: ruleID}
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	revert, ok := c.reverts[key]
+	if !ok || now.Sub(revert.recorded) >= AutoModRevertTTL {
+		c.reverts[key] = AutoModRuleRevert{RuleID: ruleID, Definition: definition, Deleted: deleted, recorded: now}
+		return
+	}
+
+	revert.Deleted = revert.Deleted || deleted
+	c.reverts[key] = revert
+}
+
+// TakeRevert removes and returns the recorded definition of a rule.
+func (c *AutoModRuleCache) TakeRevert(guildID, ruleID uint64) (AutoModRuleRevert, bool) {
+	key := autoModRuleKey{guildID: guildID, ruleID: ruleID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	revert, ok := c.reverts[key]
+	delete(c.reverts, key)
+	if !ok || time.Since(revert.recorded) >= AutoModRevertTTL {
+		return AutoModRuleRevert{}, false
+	}
+	return revert, true
+}
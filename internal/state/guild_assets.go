@@ -0,0 +1,77 @@
+package state
+
+import (
+	"sync"
+)
+
+// AssetKind separates emojis from stickers in the asset cache.
+type AssetKind uint8
+
+const (
+	AssetEmoji AssetKind = iota
+	AssetSticker
+)
+
+type assetKey struct {
+	guildID uint64
+	kind    AssetKind
+}
+
+// AssetDiff is what changed between two snapshots of a guild's emojis or stickers.
+type AssetDiff struct {
+	Created []uint64
+	Updated []uint64
+	Deleted map[uint64]string // ID -> name, the name is gone from the new snapshot
+}
+
+// GuildAssetCache keeps each guild's emoji and sticker names so the full
+// lists sent in GUILD_EMOJIS_UPDATE and GUILD_STICKERS_UPDATE can be diffed.
+type GuildAssetCache struct {
+	mu     sync.Mutex
+	assets map[assetKey]map[uint64]string
+}
+
+var globalGuildAssets *GuildAssetCache
+
+func InitGuildAssetCache() {
+	globalGuildAssets = &GuildAssetCache{
+		assets: make(map[assetKey]map[uint64]string),
+	}
+}
+
+func GetGuildAssetCache() *GuildAssetCache {
+	return globalGuildAssets
+}
+
+// Update replaces the guild's assets of a kind (ID -> name) and returns what
+// changed. known is false the first time the guild's assets are seen.
+func (c *GuildAssetCache) Update(guildID uint64, kind AssetKind, assets map[uint64]string) (diff AssetDiff, known bool) {
+	key := assetKey{guildID: guildID, kind: kind}
+
+	c.mu.Lock()
+	before, known := c.assets[key]
+	c.assets[key] = assets
+	c.mu.Unlock()
+
+	if !known {
+		return diff, false
+	}
+
+	for id, name := range assets {
+		prev, existed := before[id]
+		if !existed {
+			diff.Created = append(diff.Created, id)
+		} else if prev != name {
+			diff.Updated = append(diff.Updated, id)
+		}
+	}
+	for id, name := range before {
+		if _, exists := assets[id]; !exists {
+			if diff.Deleted == nil {
+				diff.Deleted = make(map[uint64]string)
+			}
+			diff.Deleted[id] = name
+		}
+	}
+	return diff, true
+}
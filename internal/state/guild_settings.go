@@ -0,0 +1,171 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// Guild setting fields tracked for tampering, as bits of a change mask
+const (
+	GuildFieldName uint64 = 1 << iota
+	GuildFieldIcon
+	GuildFieldVanityCode
+	GuildFieldVerificationLevel
+	GuildFieldMFALevel
+	GuildFieldExplicitContentFilter
+	GuildFieldSystemChannel
+	GuildFieldRulesChannel
+)
+
+// GuildSettingsRevertTTL is how long a settings change stays revertible.
+const GuildSettingsRevertTTL = time.Hour
+
+// GuildSettings is the subset of guild settings an attacker can abuse.
+type GuildSettings struct {
+	Name                  string
+	Icon                  string // icon hash, not image data
+	VanityCode            string
+	VerificationLevel     uint8
+	MFALevel              uint8
+	ExplicitContentFilter uint8
+	SystemChannelID       uint64
+	RulesChannelID        uint64
+}
+
+// Diff returns the mask of fields that differ between s and other.
+func (s *GuildSettings) Diff(other *GuildSettings) uint64 {
+	changed := uint64(0)
+	if s.Name != other.Name {
+		changed |= GuildFieldName
+	}
+	if s.Icon != other.Icon {
+		changed |= GuildFieldIcon
+	}
+	if s.VanityCode != other.VanityCode {
+		changed |= GuildFieldVanityCode
+	}
+	if s.VerificationLevel != other.VerificationLevel {
+		changed |= GuildFieldVerificationLevel
+	}
+	if s.MFALevel != other.MFALevel {
+		changed |= GuildFieldMFALevel
+	}
+	if s.ExplicitContentFilter != other.ExplicitContentFilter {
+		changed |= GuildFieldExplicitContentFilter
+	}
+	if s.SystemChannelID != other.SystemChannelID {
+		changed |= GuildFieldSystemChannel
+	}
+	if s.RulesChannelID != other.RulesChannelID {
+		changed |= GuildFieldRulesChannel
+	}
+	return changed
+}
+
+// GuildSettingsRevert is the before-image of the fields an actor changed.
+type GuildSettingsRevert struct {
+	Before   GuildSettings
+	Fields   uint64
+	recorded time.Time
+}
+
+// GuildSettingsCache keeps each guild's current settings, so GUILD_UPDATE can
+// be diffed, and the before-image of every actor's changes, so the
+// dispatcher can put them back.
+type GuildSettingsCache struct {
+	mu      sync.Mutex
+	current map[uint64]GuildSettings
+	reverts map[actorKey]GuildSettingsRevert
+}
+
+var globalGuildSettings *GuildSettingsCache
+
+func InitGuildSettingsCache() {
+	globalGuildSettings = &GuildSettingsCache{
+		current: make(map[uint64]GuildSettings),
+		reverts: make(map[actorKey]GuildSettingsRevert),
+	}
+}
+
+func GetGuildSettingsCache() *GuildSettingsCache {
+	return globalGuildSettings
+}
+
+// Update stores the guild's new settings and returns the previous ones with
+// the mask of changed fields. known is false the first time a guild is seen.
+func (c *GuildSettingsCache) Update(guildID uint64, settings GuildSettings) (before GuildSettings, changed uint64, known bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	before, known = c.current[guildID]
+	c.current[guildID] = settings
+	if !known {
+		return before, 0, false
+	}
+	return before, before.Diff(&settings), true
+}
+
+// RecordRevert remembers the before-image of fields changed by actorID. A
+// field keeps its oldest value until taken, since later values are the
+// actor's own.
+func (c *GuildSettingsCache) RecordRevert(guildID, actorID uint64, before GuildSettings, fields uint64) {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	revert, ok := c.reverts[key]
+	if !ok || now.Sub(revert.recorded) >= GuildSettingsRevertTTL {
+		c.reverts[key] = GuildSettingsRevert{Before: before, Fields: fields, recorded: now}
+		return
+	}
+
+	fresh := fields &^ revert.Fields
+	revert.Before.merge(&before, fresh)
+	revert.Fields |= fresh
+	c.reverts[key] = revert
+}
+
+// TakeRevert removes and returns the before-image recorded for the actor.
+func (c *GuildSettingsCache) TakeRevert(guildID, actorID uint64) (GuildSettingsRevert, bool) {
+	key := actorKey{guildID: guildID, actorID: actorID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	revert, ok := c.reverts[key]
+	delete(c.reverts, key)
+	if !ok || time.Since(revert.recorded) >= GuildSettingsRevertTTL {
+		return GuildSettingsRevert{}, false
+	}
+	return revert, true
+}
+
+// merge copies the given fields from other into s.
+func (s *GuildSettings) merge(other *GuildSettings, fields uint64) {
+	if fields&GuildFieldName != 0 {
+		s.Name = other.Name
+	}
+	if fields&GuildFieldIcon != 0 {
+		s.Icon = other.Icon
+	}
+	if fields&GuildFieldVanityCode != 0 {
+		s.VanityCode = other.VanityCode
+	}
+	if fields&GuildFieldVerificationLevel != 0 {
+		s.VerificationLevel = other.VerificationLevel
+	}
+	if fields&GuildFieldMFALevel != 0 {
+		s.MFALevel = other.MFALevel
+	}
+	if fields&GuildFieldExplicitContentFilter != 0 {
+		s.ExplicitContentFilter = other.ExplicitContentFilter
+	}
+	if fields&GuildFieldSystemChannel != 0 {
+		s.SystemChannelID = other.SystemChannelID
+	}
+	if fields&GuildFieldRulesChannel != 0 {
+		s.RulesChannelID = other.RulesChannelID
+	}
+}
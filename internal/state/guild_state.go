@@ -44,6 +44,7 @@ type GuildProfile struct {
 type GuildState struct {
 	counters [MaxGuilds]GuildCounters
 	profiles [MaxGuilds]GuildProfile
+	windows  [MaxGuilds]WindowSet
 }
 
 var globalGuildState *GuildState
@@ -92,7 +93,23 @@ func (g *GuildState) IncrementPermChanges(guildIndex uint32) uint32 {
 	return atomic.AddUint32(&g.counters[guildIndex&GuildMask].PermChange, 1)
 }
 
+// RecordInWindow counts an event of the given class and returns the guild-wide rate within the window
+func (g *GuildState) RecordInWindow(guildIndex uint32, class uint8, now, windowNs int64) uint32 {
+	return g.windows[guildIndex&GuildMask].Add(class, now, windowNs)
+}
+
+// RecordWeightedInWindow counts an event of the given class with weight n
+func (g *GuildState) RecordWeightedInWindow(guildIndex uint32, class uint8, now, windowNs int64, n uint32) uint32 {
+	return g.windows[guildIndex&GuildMask].AddN(class, now, windowNs, n)
+}
+
+// AddMemberRemovals adds n to the guild's lifetime member removal count
+func (g *GuildState) AddMemberRemovals(guildIndex uint32, n uint32) uint32 {
+	return atomic.AddUint32(&g.counters[guildIndex&GuildMask].MemberRemove, n)
+}
+
 func (g *GuildState) ResetCounters(guildIndex uint32) {
+	g.windows[guildIndex&GuildMask].Reset()
 	c := &g.counters[guildIndex&GuildMask]
 	atomic.StoreUint32(&c.BanCount, 0)
 	atomic.StoreUint32(&c.KickCount, 0)
@@ -109,47 +109,6 @@ func (g *GuildIDMap) GetIndex(guildID uint64) uint32 {
 	return 0
 }
 
-type ActorIDMap struct {
-	ids    [MaxActors]uint64
-	lookup map[uint64]uint32
-}
-
-var globalActorIDMap *ActorIDMap
-
-func InitActorIDMap() {
-	globalActorIDMap = &ActorIDMap{
-		lookup: make(map[uint64]uint32, MaxActors),
-	}
-}
-
-func GetActorIDMap() *ActorIDMap {
-	return globalActorIDMap
-}
-
-func (a *ActorIDMap) Register(actorID uint64) uint32 {
-	if idx, exists := a.lookup[actorID]; exists {
-		return idx
-	}
-
-	// Reserve index 0 as "not found" sentinel.
-	// Indices start at 1 to avoid actorIndex=0 being treated as both valid and invalid.
-	idx := uint32(len(a.lookup) + 1)
-	if idx >= MaxActors {
-		return 0
-	}
-
-	a.ids[idx] = actorID
-	a.lookup[actorID] = idx
-	return idx
-}
-
-func (a *ActorIDMap) GetIndex(actorID uint64) uint32 {
-	if idx, exists := a.lookup[actorID]; exists {
-		return idx
-	}
-	return 0
-}
-
 // Bot ID storage
 var globalBotID uint64
 
@@ -160,11 +119,3 @@ func SetBotID(botID uint64) {
 func GetBotID() uint64 {
 	return globalBotID
 }
-
-// ClearGuild removes all actor mappings for a specific guild
-// Called when bot is removed and re-added to reset state
-func (a *ActorIDMap) ClearGuild(guildID uint64) {
-	// Note: In current implementation, we don't track guild per actor in the map
-	// The GuildID is stored in ActorProfile. This is a placeholder for future enhancement
-	// For now, we rely on ActorState counter clearing
-}
@@ -0,0 +1,164 @@
+package state
+
+import (
+	"sync"
+)
+
+// MemberRoleResolver looks a member's roles up outside the cache, e.g. in the
+// gateway session state. It must not make REST calls; it runs on the hot path.
+type MemberRoleResolver func(guildID, userID uint64) ([]uint64, bool)
+
+type guildMemberRoles struct {
+	mu    sync.RWMutex
+	roles map[uint64][]uint64
+}
+
+// MemberRoleCache maps (guild, member) to the member's role IDs so the
+// correlator can honour role whitelists without asking Discord.
+type MemberRoleCache struct {
+	mu       sync.RWMutex
+	guilds   map[uint64]*guildMemberRoles
+	resolver MemberRoleResolver
+}
+
+var globalMemberRoles *MemberRoleCache
+
+func InitMemberRoleCache() {
+	globalMemberRoles = &MemberRoleCache{
+		guilds: make(map[uint64]*guildMemberRoles),
+	}
+}
+
+func GetMemberRoleCache() *MemberRoleCache {
+	return globalMemberRoles
+}
+
+// SetResolver installs the fallback used when a member is not cached yet.
+func (c *MemberRoleCache) SetResolver(resolver MemberRoleResolver) {
+	c.mu.Lock()
+	c.resolver = resolver
+	c.mu.Unlock()
+}
+
+func (c *MemberRoleCache) guild(guildID uint64, create bool) *guildMemberRoles {
+	c.mu.RLock()
+	g := c.guilds[guildID]
+	c.mu.RUnlock()
+	if g != nil || !create {
+		return g
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if g = c.guilds[guildID]; g == nil {
+		g = &guildMemberRoles{roles: make(map[uint64][]uint64)}
+		c.guilds[guildID] = g
+	}
+	return g
+}
+
+// Set replaces the cached roles for a member.
+func (c *MemberRoleCache) Set(guildID, userID uint64, roles []uint64) {
+	g := c.guild(guildID, true)
+	g.mu.Lock()
+	g.roles[userID] = roles
+	g.mu.Unlock()
+}
+
+func (c *MemberRoleCache) Remove(guildID, userID uint64) {
+	g := c.guild(guildID, false)
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	delete(g.roles, userID)
+	g.mu.Unlock()
+}
+
+// RemoveRole strips a deleted role from every cached member of the guild.
+func (c *MemberRoleCache) RemoveRole(guildID, roleID uint64) {
+	g := c.guild(guildID, false)
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for userID, roles := range g.roles {
+		for i, id := range roles {
+			if id == roleID {
+				kept := make([]uint64, 0, len(roles)-1)
+				kept = append(kept, roles[:i]...)
+				g.roles[userID] = append(kept, roles[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// CountRole returns how many cached members of the guild hold roleID, and
+// how many members are cached in total.
+func (c *MemberRoleCache) CountRole(guildID, roleID uint64) (holders, members int) {
+	g := c.guild(guildID, false)
+	if g == nil {
+		return 0, 0
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, roles := range g.roles {
+		for _, id := range roles {
+			if id == roleID {
+				holders++
+				break
+			}
+		}
+	}
+	return holders, len(g.roles)
+}
+
+func (c *MemberRoleCache) ClearGuild(guildID uint64) {
+	c.mu.Lock()
+	delete(c.guilds, guildID)
+	c.mu.Unlock()
+}
+
+// Cached returns the member's cached role IDs without consulting the
+// resolver, for callers that need the roles as they were before an update.
+func (c *MemberRoleCache) Cached(guildID, userID uint64) ([]uint64, bool) {
+	g := c.guild(guildID, false)
+	if g == nil {
+		return nil, false
+	}
+	g.mu.RLock()
+	roles, ok := g.roles[userID]
+	g.mu.RUnlock()
+	return roles, ok
+}
+
+// Roles returns the member's role IDs, consulting the resolver on a miss.
+// The returned slice must not be modified.
+func (c *MemberRoleCache) Roles(guildID, userID uint64) []uint64 {
+	if g := c.guild(guildID, false); g != nil {
+		g.mu.RLock()
+		roles, ok := g.roles[userID]
+		g.mu.RUnlock()
+		if ok {
+			return roles
+		}
+	}
+
+	c.mu.RLock()
+	resolver := c.resolver
+	c.mu.RUnlock()
+	if resolver == nil {
+		return nil
+	}
+
+	roles, ok := resolver(guildID, userID)
+	if !ok {
+		return nil
+	}
+	c.Set(guildID, userID, roles)
+	return roles
+}
@@ -0,0 +1,98 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// MaxTrackedOverwrites bounds how many overwrite before-images are kept per actor.
+	MaxTrackedOverwrites = 256
+
+	// OverwriteTrackTTL is how long an overwrite change stays revertible.
+	OverwriteTrackTTL = 24 * time.Hour
+)
+
+// OverwriteImage is a channel permission overwrite as it was before an actor
+// changed it. Existed is false when the actor created the overwrite, in which
+// case restoring it means deleting it.
+type OverwriteImage struct {
+	ChannelID uint64
+	TargetID  uint64
+	Type      uint8 // 0 = role, 1 = member
+	Allow     uint64
+	Deny      uint64
+	Existed   bool
+	recorded  time.Time
+}
+
+// OverwriteRegistry keeps the before-image of every dangerous overwrite an
+// actor made so the dispatcher can restore them once the actor is punished.
+type OverwriteRegistry struct {
+	mu      sync.Mutex
+	byActor map[actorKey][]OverwriteImage
+}
+
+var globalOverwriteRegistry *OverwriteRegistry
+
+func InitOverwriteRegistry() {
+	globalOverwriteRegistry = &OverwriteRegistry{
+		byActor: make(map[actorKey][]OverwriteImage),
+	}
+}
+
+func GetOverwriteRegistry() *OverwriteRegistry {
+	return globalOverwriteRegistry
+}
+
+// Record stores the before-image of an overwrite changed by actorID. Only the
+// first change to an overwrite is kept, since later ones would capture the
+// actor's own tampering.
+func (r *OverwriteRegistry) Record(guildID, actorID uint64, image OverwriteImage) {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+	image.recorded = now
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, img := range r.byActor[key] {
+		if img.ChannelID == image.ChannelID && img.TargetID == image.TargetID && now.Sub(img.recorded) < OverwriteTrackTTL {
+			return
+		}
+	}
+
+	// Drop expired entries, and the oldest one if the actor is at capacity
+	images := r.byActor[key][:0]
+	for _, img := range r.byActor[key] {
+		if now.Sub(img.recorded) < OverwriteTrackTTL {
+			images = append(images, img)
+		}
+	}
+	if len(images) >= MaxTrackedOverwrites {
+		images = append(images[:0], images[1:]...)
+	}
+
+	r.byActor[key] = append(images, image)
+}
+
+// Take removes and returns the before-images recorded for the actor in the
+// guild within the tracking window.
+func (r *OverwriteRegistry) Take(guildID, actorID uint64) []OverwriteImage {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	images := r.byActor[key]
+	delete(r.byActor, key)
+
+	kept := make([]OverwriteImage, 0, len(images))
+	for _, img := range images {
+		if now.Sub(img.recorded) < OverwriteTrackTTL {
+			kept = append(kept, img)
+		}
+	}
+	return kept
+}
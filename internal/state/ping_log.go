@@ -0,0 +1,82 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// MaxTrackedPings bounds how many ping messages are remembered per actor.
+	MaxTrackedPings = 200
+
+	// PingTrackTTL is how long a ping message stays eligible for the purge.
+	PingTrackTTL = 10 * time.Minute
+)
+
+// pingMessage is a message that pinged @everyone, @here or roles.
+type pingMessage struct {
+	channelID uint64
+	messageID uint64
+	sent      time.Time
+}
+
+// PingLog remembers each actor's recent mass-ping messages so they can be
+// bulk-deleted once the actor goes over the limit.
+type PingLog struct {
+	mu      sync.Mutex
+	byActor map[actorKey][]pingMessage
+}
+
+var globalPingLog *PingLog
+
+func InitPingLog() {
+	globalPingLog = &PingLog{
+		byActor: make(map[actorKey][]pingMessage),
+	}
+}
+
+func GetPingLog() *PingLog {
+	return globalPingLog
+}
+
+// Record remembers a ping message sent by actorID in guildID.
+func (l *PingLog) Record(guildID, actorID, channelID, messageID uint64) {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop expired entries, and the oldest one if the actor is at capacity
+	pings := l.byActor[key][:0]
+	for _, ping := range l.byActor[key] {
+		if now.Sub(ping.sent) < PingTrackTTL {
+			pings = append(pings, ping)
+		}
+	}
+	if len(pings) >= MaxTrackedPings {
+		pings = append(pings[:0], pings[1:]...)
+	}
+
+	l.byActor[key] = append(pings, pingMessage{channelID: channelID, messageID: messageID, sent: now})
+}
+
+// Take removes and returns the actor's ping messages within the tracking
+// window, grouped by channel.
+func (l *PingLog) Take(guildID, actorID uint64) map[uint64][]uint64 {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	l.mu.Lock()
+	pings := l.byActor[key]
+	delete(l.byActor, key)
+	l.mu.Unlock()
+
+	byChannel := make(map[uint64][]uint64)
+	for _, ping := range pings {
+		if now.Sub(ping.sent) < PingTrackTTL {
+			byChannel[ping.channelID] = append(byChannel[ping.channelID], ping.messageID)
+		}
+	}
+	return byChannel
+}
@@ -20,6 +20,16 @@ func InitAll() error {
 	InitHazardScores()
 	InitGuildIDMap()
 	InitActorIDMap()
+	InitMemberRoleCache()
+	InitWebhookRegistry()
+	InitScheduledEventRegistry()
+	InitPingLog()
+	InitRecentDeletes()
+	InitSelfRoleCache()
+	InitOverwriteRegistry()
+	InitGuildSettingsCache()
+	InitGuildAssetCache()
+	InitAutoModRuleCache()
 	InitEventLookup()
 
 	GlobalState = &PreallocatedState{
@@ -65,6 +75,7 @@ func ResetAllCounters() {
 	as := GetActorState()
 	for i := range as.counters {
 		as.counters[i] = ActorCounters{}
+		as.windows[i].Reset()
 	}
 
 	hs := GetHazardScores()
@@ -0,0 +1,64 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// RecentDeleteTTL is how long a deleted channel or role is remembered.
+const RecentDeleteTTL = 5 * time.Minute
+
+type deleteKey struct {
+	guildID  uint64
+	entityID uint64
+}
+
+// RecentDeletes remembers channels and roles the gateway reported deleted.
+// The gateway state forgets them as soon as the delete arrives, so this is
+// what tells a real delete apart from an audit entry about a target that
+// never existed.
+type RecentDeletes struct {
+	mu      sync.Mutex
+	deleted map[deleteKey]time.Time
+	records uint32
+}
+
+var globalRecentDeletes *RecentDeletes
+
+func InitRecentDeletes() {
+	globalRecentDeletes = &RecentDeletes{
+		deleted: make(map[deleteKey]time.Time),
+	}
+}
+
+func GetRecentDeletes() *RecentDeletes {
+	return globalRecentDeletes
+}
+
+// Record notes that entityID was deleted from guildID.
+func (r *RecentDeletes) Record(guildID, entityID uint64) {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Only sweep every 100th record so a mass delete stays linear
+	r.records++
+	if r.records%100 == 0 {
+		for key, at := range r.deleted {
+			if now.Sub(at) > RecentDeleteTTL {
+				delete(r.deleted, key)
+			}
+		}
+	}
+	r.deleted[deleteKey{guildID: guildID, entityID: entityID}] = now
+}
+
+// WasDeleted reports whether entityID was deleted from guildID recently.
+func (r *RecentDeletes) WasDeleted(guildID, entityID uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	at, ok := r.deleted[deleteKey{guildID: guildID, entityID: entityID}]
+	return ok && time.Since(at) <= RecentDeleteTTL
+}
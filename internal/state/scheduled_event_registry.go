@@ -0,0 +1,113 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// MaxTrackedScheduledEvents bounds how many scheduled events are remembered per actor.
+	MaxTrackedScheduledEvents = 64
+
+	// ScheduledEventTrackTTL is how long a created scheduled event stays attributable to its creator.
+	ScheduledEventTrackTTL = 24 * time.Hour
+)
+
+type trackedScheduledEvent struct {
+	id      uint64
+	created time.Time
+}
+
+// ScheduledEventRegistry remembers which actor created which scheduled event
+// so the dispatcher can remove an attacker's events once they are punished.
+type ScheduledEventRegistry struct {
+	mu       sync.Mutex
+	byActor  map[actorKey][]trackedScheduledEvent
+	creators map[uint64]actorKey
+}
+
+var globalScheduledEventRegistry *ScheduledEventRegistry
+
+func InitScheduledEventRegistry() {
+	globalScheduledEventRegistry = &ScheduledEventRegistry{
+		byActor:  make(map[actorKey][]trackedScheduledEvent),
+		creators: make(map[uint64]actorKey),
+	}
+}
+
+func GetScheduledEventRegistry() *ScheduledEventRegistry {
+	return globalScheduledEventRegistry
+}
+
+// Track records a scheduled event created by actorID in guildID.
+func (r *ScheduledEventRegistry) Track(guildID, actorID, eventID uint64) {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Drop expired entries, and the oldest one if the actor is at capacity
+	events := r.byActor[key][:0]
+	for _, ev := range r.byActor[key] {
+		if now.Sub(ev.created) < ScheduledEventTrackTTL {
+			events = append(events, ev)
+		} else {
+			delete(r.creators, ev.id)
+		}
+	}
+	if len(events) >= MaxTrackedScheduledEvents {
+		delete(r.creators, events[0].id)
+		events = append(events[:0], events[1:]...)
+	}
+
+	r.byActor[key] = append(events, trackedScheduledEvent{id: eventID, created: now})
+	r.creators[eventID] = key
+}
+
+// Forget drops a scheduled event that no longer exists.
+func (r *ScheduledEventRegistry) Forget(eventID uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.creators[eventID]
+	if !ok {
+		return
+	}
+	delete(r.creators, eventID)
+
+	events := r.byActor[key]
+	for i, ev := range events {
+		if ev.id == eventID {
+			events = append(events[:i], events[i+1:]...)
+			break
+		}
+	}
+	if len(events) == 0 {
+		delete(r.byActor, key)
+	} else {
+		r.byActor[key] = events
+	}
+}
+
+// Take removes and returns the scheduled events the actor created in the guild
+// within the tracking window.
+func (r *ScheduledEventRegistry) Take(guildID, actorID uint64) []uint64 {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := r.byActor[key]
+	delete(r.byActor, key)
+
+	ids := make([]uint64, 0, len(events))
+	for _, ev := range events {
+		delete(r.creators, ev.id)
+		if now.Sub(ev.created) < ScheduledEventTrackTTL {
+			ids = append(ids, ev.id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,45 @@
+package state
+
+import (
+	"sync"
+)
+
+// SelfRoleStatus is what the antinuke bot's own roles give it in a guild:
+// the permissions every ban, kick and restore depends on, and the position
+// that decides whom it can act on.
+type SelfRoleStatus struct {
+	TopRoleID   uint64
+	Position    int
+	Permissions uint64
+	Above       []uint64 // roles positioned above the bot's top role
+}
+
+// SelfRoleCache keeps the bot's last known status per guild so a change can
+// be diffed down to the exact permissions and positions lost.
+type SelfRoleCache struct {
+	mu     sync.Mutex
+	guilds map[uint64]SelfRoleStatus
+}
+
+var globalSelfRoles *SelfRoleCache
+
+func InitSelfRoleCache() {
+	globalSelfRoles = &SelfRoleCache{
+		guilds: make(map[uint64]SelfRoleStatus),
+	}
+}
+
+func GetSelfRoleCache() *SelfRoleCache {
+	return globalSelfRoles
+}
+
+// Update stores the bot's new status in the guild and returns the previous
+// one. known is false the first time the guild is seen.
+func (c *SelfRoleCache) Update(guildID uint64, status SelfRoleStatus) (before SelfRoleStatus, known bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	before, known = c.guilds[guildID]
+	c.guilds[guildID] = status
+	return before, known
+}
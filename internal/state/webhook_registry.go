@@ -0,0 +1,122 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// MaxTrackedWebhooks bounds how many webhooks are remembered per actor.
+	MaxTrackedWebhooks = 64
+
+	// WebhookTrackTTL is how long a created webhook stays attributable to its creator.
+	WebhookTrackTTL = 24 * time.Hour
+)
+
+type trackedWebhook struct {
+	id      uint64
+	created time.Time
+}
+
+// WebhookRegistry remembers which actor created which webhook so the
+// dispatcher can remove an attacker's webhooks once they are punished.
+type WebhookRegistry struct {
+	mu       sync.Mutex
+	byActor  map[actorKey][]trackedWebhook
+	creators map[uint64]actorKey
+}
+
+var globalWebhookRegistry *WebhookRegistry
+
+func InitWebhookRegistry() {
+	globalWebhookRegistry = &WebhookRegistry{
+		byActor:  make(map[actorKey][]trackedWebhook),
+		creators: make(map[uint64]actorKey),
+	}
+}
+
+func GetWebhookRegistry() *WebhookRegistry {
+	return globalWebhookRegistry
+}
+
+// Track records a webhook created by actorID in guildID.
+func (r *WebhookRegistry) Track(guildID, actorID, webhookID uint64) {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Drop expired entries, and the oldest one if the actor is at capacity
+	hooks := r.byActor[key][:0]
+	for _, hook := range r.byActor[key] {
+		if now.Sub(hook.created) < WebhookTrackTTL {
+			hooks = append(hooks, hook)
+		} else {
+			delete(r.creators, hook.id)
+		}
+	}
+	if len(hooks) >= MaxTrackedWebhooks {
+		delete(r.creators, hooks[0].id)
+		hooks = append(hooks[:0], hooks[1:]...)
+	}
+
+	r.byActor[key] = append(hooks, trackedWebhook{id: webhookID, created: now})
+	r.creators[webhookID] = key
+}
+
+// Creator returns who created a tracked webhook.
+func (r *WebhookRegistry) Creator(webhookID uint64) (actorID uint64, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.creators[webhookID]
+	return key.actorID, ok
+}
+
+// Forget drops a webhook that no longer exists.
+func (r *WebhookRegistry) Forget(webhookID uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.creators[webhookID]
+	if !ok {
+		return
+	}
+	delete(r.creators, webhookID)
+
+	hooks := r.byActor[key]
+	for i, hook := range hooks {
+		if hook.id == webhookID {
+			hooks = append(hooks[:i], hooks[i+1:]...)
+			break
+		}
+	}
+	if len(hooks) == 0 {
+		delete(r.byActor, key)
+	} else {
+		r.byActor[key] = hooks
+	}
+}
+
+// Take removes and returns the webhooks the actor created in the guild
+// within the tracking window.
+func (r *WebhookRegistry) Take(guildID, actorID uint64) []uint64 {
+	key := actorKey{guildID: guildID, actorID: actorID}
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hooks := r.byActor[key]
+	delete(r.byActor, key)
+
+	ids := make([]uint64, 0, len(hooks))
+	for _, hook := range hooks {
+		delete(r.creators, hook.id)
+		if now.Sub(hook.created) < WebhookTrackTTL {
+			ids = append(ids, hook.id)
+		}
+	}
+	return ids
+}
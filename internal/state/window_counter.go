@@ -0,0 +1,125 @@
+package state
+
+import (
+	"sync/atomic"
+)
+
+const (
+	// WindowBuckets is the number of ring slots per counter; the window is
+	// split into this many equal buckets that expire one at a time.
+	WindowBuckets = 8
+
+	// MaxEventClasses bounds the per-entity window table. Classes are the
+	// ingest event type IDs, so each event type keeps its own window.
+	MaxEventClasses = 32
+)
+
+// WindowCounter is a fixed-size bucketed ring that counts events seen within
+// the trailing window. It never allocates and is not safe for concurrent use;
+// its WindowSet keeps every write on the correlator.
+type WindowCounter struct {
+	headStart int64
+	widthNs   int64
+	head      uint32
+	_         uint32
+	counts    [WindowBuckets]uint16
+}
+
+// Add records one event at now and returns the number of events in the window.
+func (w *WindowCounter) Add(now, windowNs int64) uint32 {
+	return w.AddN(now, windowNs, 1)
+}
+
+// AddN records an event that weighs n (e.g. members removed by one prune)
+// and returns the weighted total in the window. Buckets saturate.
+func (w *WindowCounter) AddN(now, windowNs int64, n uint32) uint32 {
+	w.advance(now, windowNs)
+	total := uint32(w.counts[w.head]) + n
+	if total > uint32(^uint16(0)) {
+		total = uint32(^uint16(0))
+	}
+	w.counts[w.head] = uint16(total)
+	return w.sum()
+}
+
+// Count returns the number of events in the window ending at now.
+func (w *WindowCounter) Count(now, windowNs int64) uint32 {
+	w.advance(now, windowNs)
+	return w.sum()
+}
+
+func (w *WindowCounter) Reset() {
+	*w = WindowCounter{}
+}
+
+func (w *WindowCounter) advance(now, windowNs int64) {
+	width := windowNs / WindowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	start := now - now%width
+
+	// A changed window (admin edited the limit) invalidates the bucket layout
+	if w.widthNs != width {
+		*w = WindowCounter{headStart: start, widthNs: width}
+		return
+	}
+
+	steps := (start - w.headStart) / width
+	if steps <= 0 {
+		return
+	}
+
+	if steps >= WindowBuckets {
+		w.counts = [WindowBuckets]uint16{}
+		w.head = 0
+	} else {
+		for i := int64(0); i < steps; i++ {
+			w.head = (w.head + 1) % WindowBuckets
+			w.counts[w.head] = 0
+		}
+	}
+	w.headStart = start
+}
+
+func (w *WindowCounter) sum() uint32 {
+	total := uint32(0)
+	for _, c := range w.counts {
+		total += uint32(c)
+	}
+	return total
+}
+
+// WindowSet holds one counter per event class for a single guild or actor.
+// Only the correlator records into it. Other goroutines (slot eviction, state
+// clears from handlers) call Reset, which merely requests a reset; the
+// correlator applies it before its next write, so the buckets are never
+// written from two goroutines.
+type WindowSet struct {
+	counters  [MaxEventClasses]WindowCounter
+	resetReq  uint32
+	resetSeen uint32
+}
+
+func (ws *WindowSet) Add(class uint8, now, windowNs int64) uint32 {
+	ws.applyReset()
+	return ws.counters[class%MaxEventClasses].Add(now, windowNs)
+}
+
+func (ws *WindowSet) AddN(class uint8, now, windowNs int64, n uint32) uint32 {
+	ws.applyReset()
+	return ws.counters[class%MaxEventClasses].AddN(now, windowNs, n)
+}
+
+// Reset asks the correlator to clear every counter before its next write.
+// It is safe to call from any goroutine.
+func (ws *WindowSet) Reset() {
+	atomic.AddUint32(&ws.resetReq, 1)
+}
+
+func (ws *WindowSet) applyReset() {
+	if req := atomic.LoadUint32(&ws.resetReq); req != ws.resetSeen {
+		ws.counters = [MaxEventClasses]WindowCounter{}
+		ws.resetSeen = req
+	}
+}